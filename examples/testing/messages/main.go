@@ -118,9 +118,9 @@ func main() {
 			}
 
 			fmt.Printf(
-				"\tCode: %v \n\tMessage: %v \n\tVersion: %v \n\tScore: %v \n\tSpam: %v \n\tThreshold: %v \n\tDetails: %v",
+				"\tCode: %v \n\tMessage: %v \n\tVersion: %v \n\tScore: %v \n\tSpam: %v \n\tThreshold: %v \n\tDetails:\n%v",
 				r.Report.ResponseCode, r.Report.ResponseMessage, r.Report.ResponseVersion, r.Report.Score,
-				r.Report.Spam, r.Report.Threshold, r.Report.Details,
+				r.Report.Spam, r.Report.Threshold, r.RulesTable(),
 			)
 
 		// Get message body
@@ -0,0 +1,58 @@
+// The command shows how to exercise a webhook handler offline.
+// It either generates fixture events or replays a recorded JSON Lines file
+// against a local webhook endpoint.
+//
+// It's runnable with the following commands:
+//
+// go run . -generate delivery,open,click > events.jsonl
+// go run . -url http://localhost:8080/webhooks -secret signing_secret events.jsonl
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/vorobeyme/mailtrap-go/mailtrap"
+)
+
+func main() {
+	var (
+		url       = flag.String("url", "", "webhook endpoint URL")
+		secret    = flag.String("secret", "", "webhook signing secret")
+		batchSize = flag.Int("batch", 100, "number of events per request")
+		generate  = flag.String("generate", "", "comma-separated event types to generate")
+		email     = flag.String("email", "john@example.com", "recipient of the generated events")
+	)
+	flag.Parse()
+
+	if *generate != "" {
+		g := mailtrap.NewFixtureGenerator(1)
+		g.Email = *email
+		events := g.Events("", strings.Split(*generate, ",")...)
+		if err := mailtrap.NewJSONLinesSink(os.Stdout).Write(events.Events); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *url == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	replayer := &mailtrap.WebhookReplayer{URL: *url, Secret: *secret, BatchSize: *batchSize}
+	n, err := replayer.Replay(f)
+	if err != nil {
+		log.Fatalf("Replayed %d events: %v", n, err)
+	}
+	fmt.Printf("Replayed %d events.\n", n)
+}
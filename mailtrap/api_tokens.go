@@ -0,0 +1,139 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type APITokensServiceContract interface {
+	List(accountID int) ([]*APIToken, *Response, error)
+	Get(accountID, tokenID int) (*APIToken, *Response, error)
+	Create(accountID int, tokenReq *CreateAPITokenRequest) (*APIToken, *Response, error)
+	Reset(accountID, tokenID int) (*APIToken, *Response, error)
+	Delete(accountID, tokenID int) (*Response, error)
+}
+
+type APITokensService struct {
+	client *client
+}
+
+var _ APITokensServiceContract = &APITokensService{}
+
+// Access levels of the token resources.
+const (
+	AccessLevelViewer = 10
+	AccessLevelAdmin  = 100
+)
+
+// APIToken represents a Mailtrap API token.
+type APIToken struct {
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	Last4Digits string              `json:"last_4_digits"`
+	CreatedBy   string              `json:"created_by"`
+	ExpiresAt   Timestamp           `json:"expires_at"`
+	Resources   []*APITokenResource `json:"resources"`
+
+	// Token is the token value, returned only on create and reset.
+	Token string `json:"token,omitempty"`
+}
+
+// APITokenResource represents a resource the token has access to.
+type APITokenResource struct {
+	// resource_type can be account, project, inbox or mailsend_domain
+	ResourceType string `json:"resource_type"`
+	ResourceID   int    `json:"resource_id"`
+	// access_level can be 100 (admin) or 10 (viewer)
+	AccessLevel int `json:"access_level"`
+}
+
+// CreateAPITokenRequest represents the request to create API token.
+type CreateAPITokenRequest struct {
+	Name      string              `json:"name"`
+	Resources []*APITokenResource `json:"resources"`
+}
+
+// List returns the API tokens of the account. The token values are not returned.
+func (s *APITokensService) List(accountID int) ([]*APIToken, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/api_tokens", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tokens []*APIToken
+	res, err := s.client.Do(req, &tokens)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return tokens, res, nil
+}
+
+// Get returns the API token by ID. The token value is not returned.
+func (s *APITokensService) Get(accountID, tokenID int) (*APIToken, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/api_tokens/%d", accountID, tokenID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates an API token with access to the resources.
+// The returned token value can't be retrieved later.
+func (s *APITokensService) Create(
+	accountID int,
+	tokenReq *CreateAPITokenRequest,
+) (*APIToken, *Response, error) {
+	if tokenReq == nil || tokenReq.Name == "" {
+		return nil, nil, errors.New("API token 'name' is required")
+	}
+	if len(tokenReq.Resources) == 0 {
+		return nil, nil, errors.New("API token 'resources' are required")
+	}
+	for _, r := range tokenReq.Resources {
+		if r.ResourceType == "" {
+			return nil, nil, errors.New("'resource_type' is required in API token resource")
+		}
+		if r.AccessLevel != AccessLevelViewer && r.AccessLevel != AccessLevelAdmin {
+			return nil, nil, fmt.Errorf("invalid API token resource access level %d", r.AccessLevel)
+		}
+	}
+
+	u := fmt.Sprintf("/accounts/%d/api_tokens", accountID)
+	return s.makeRequest(u, http.MethodPost, tokenReq)
+}
+
+// Reset replaces the API token value with a new one, keeping its name and resources.
+// The previous value stops working immediately.
+func (s *APITokensService) Reset(accountID, tokenID int) (*APIToken, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/api_tokens/%d/reset", accountID, tokenID)
+	return s.makeRequest(u, http.MethodPost, nil)
+}
+
+// Delete removes the API token.
+func (s *APITokensService) Delete(accountID, tokenID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/api_tokens/%d", accountID, tokenID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *APITokensService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*APIToken, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var token *APIToken
+	res, err := s.client.Do(req, &token)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return token, res, nil
+}
@@ -0,0 +1,181 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAPITokensService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &APIToken{}, `{"expires_at":null}`)
+
+	want := `{
+		"id": 1,
+		"name": "CI token",
+		"last_4_digits": "a1b2",
+		"created_by": "John Doe",
+		"expires_at": "2025-01-01T00:00:00Z",
+		"resources": [
+			{"resource_type": "account", "resource_id": 3, "access_level": 100}
+		]
+	}`
+	testJSONMarshal(t, apiTokenMock(1), want)
+}
+
+func TestAPITokensService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedTokens := []*APIToken{apiTokenMock(1), apiTokenMock(2)}
+
+	mux.HandleFunc("/accounts/3/api_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedTokens)
+		fmt.Fprint(w, string(resp))
+	})
+
+	tokens, _, err := client.APITokens.List(3)
+	if err != nil {
+		t.Errorf("APITokens.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tokens, expectedTokens) {
+		t.Errorf("APITokens.List returned %+v, expected %+v", tokens, expectedTokens)
+	}
+
+	testBadPathParams(t, "APITokens.List", func() error {
+		_, _, err = client.APITokens.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "APITokens.List", &client.client, func() (*Response, error) {
+		tokens, resp, err := client.APITokens.List(3)
+		if tokens != nil {
+			t.Errorf("APITokens.List client.BaseURL.Host=%v tokens=%#v, want nil", client.baseURL.Host, tokens)
+		}
+		return resp, err
+	})
+}
+
+func TestAPITokensService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/3/api_tokens/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(apiTokenMock(1))
+		fmt.Fprint(w, string(resp))
+	})
+
+	token, _, err := client.APITokens.Get(3, 1)
+	if err != nil {
+		t.Errorf("APITokens.Get returned error: %v", err)
+	}
+
+	if expected := apiTokenMock(1); !reflect.DeepEqual(token, expected) {
+		t.Errorf("APITokens.Get returned %+v, expected %+v", token, expected)
+	}
+}
+
+func TestAPITokensService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/3/api_tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"CI token","resources":[{"resource_type":"account","resource_id":3,"access_level":100}]}`)
+		token := apiTokenMock(1)
+		token.Token = "secret-token"
+		resp, _ := json.Marshal(token)
+		fmt.Fprint(w, string(resp))
+	})
+
+	createReq := &CreateAPITokenRequest{
+		Name:      "CI token",
+		Resources: []*APITokenResource{{ResourceType: "account", ResourceID: 3, AccessLevel: AccessLevelAdmin}},
+	}
+	token, _, err := client.APITokens.Create(3, createReq)
+	if err != nil {
+		t.Errorf("APITokens.Create returned error: %v", err)
+	}
+
+	expected := apiTokenMock(1)
+	expected.Token = "secret-token"
+	if !reflect.DeepEqual(token, expected) {
+		t.Errorf("APITokens.Create returned %+v, expected %+v", token, expected)
+	}
+
+	invalid := []*CreateAPITokenRequest{
+		nil,
+		{Resources: createReq.Resources},
+		{Name: "CI token"},
+		{Name: "CI token", Resources: []*APITokenResource{{ResourceID: 3, AccessLevel: AccessLevelAdmin}}},
+		{Name: "CI token", Resources: []*APITokenResource{{ResourceType: "account", ResourceID: 3, AccessLevel: 1000}}},
+	}
+	for _, req := range invalid {
+		if _, _, err := client.APITokens.Create(3, req); err == nil {
+			t.Errorf("APITokens.Create(%+v), err = nil, want error", req)
+		}
+	}
+}
+
+func TestAPITokensService_Reset(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/3/api_tokens/1/reset", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":1,"name":"CI token","last_4_digits":"c3d4","token":"new-secret-token"}`)
+	})
+
+	token, _, err := client.APITokens.Reset(3, 1)
+	if err != nil {
+		t.Errorf("APITokens.Reset returned error: %v", err)
+	}
+
+	expected := &APIToken{ID: 1, Name: "CI token", Last4Digits: "c3d4", Token: "new-secret-token"}
+	if !reflect.DeepEqual(token, expected) {
+		t.Errorf("APITokens.Reset returned %+v, expected %+v", token, expected)
+	}
+
+	testNewRequestAndDoFail(t, "APITokens.Reset", &client.client, func() (*Response, error) {
+		token, resp, err := client.APITokens.Reset(3, 1)
+		if token != nil {
+			t.Errorf("APITokens.Reset client.BaseURL.Host=%v token=%#v, want nil", client.baseURL.Host, token)
+		}
+		return resp, err
+	})
+}
+
+func TestAPITokensService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/3/api_tokens/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.APITokens.Delete(3, 1)
+	if err != nil {
+		t.Errorf("APITokens.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "APITokens.Delete", &client.client, func() (*Response, error) {
+		return client.APITokens.Delete(3, 1)
+	})
+}
+
+func apiTokenMock(ID int) *APIToken {
+	return &APIToken{
+		ID:          ID,
+		Name:        "CI token",
+		Last4Digits: "a1b2",
+		CreatedBy:   "John Doe",
+		ExpiresAt:   NewTimestamp(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Resources:   []*APITokenResource{{ResourceType: "account", ResourceID: 3, AccessLevel: AccessLevelAdmin}},
+	}
+}
@@ -1,13 +1,24 @@
 package mailtrap
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 type AttachmentsServiceContract interface {
 	List(accountID, inboxID, messageID int) ([]*Attachment, *Response, error)
 	Get(accountID, inboxID, messageID, attachmentID int) (*Attachment, *Response, error)
+	Content(accountID, inboxID, messageID, attachmentID int) (io.ReadCloser, *Response, error)
+	WriteContentTo(w io.Writer, accountID, inboxID, messageID, attachmentID int) (int64, *Response, error)
+	Download(accountID, inboxID, messageID, attachmentID int) (*AttachmentDownload, *Response, error)
+	SaveAll(accountID, inboxID, messageID int, dir string) ([]string, error)
 }
 
 type AttachmentsService struct {
@@ -18,18 +29,18 @@ var _ AttachmentsServiceContract = &AttachmentsService{}
 
 // Attachment represents a Mailtrap attachment schema.
 type Attachment struct {
-	ID                  int    `json:"id"`
-	MessageID           int    `json:"message_id"`
-	Filename            string `json:"filename"`
-	AttachmentType      string `json:"attachment_type"`
-	ContentType         string `json:"content_type"`
-	ContentID           string `json:"content_id"`
-	TransferEncoding    string `json:"transfer_encoding"`
-	AttachmentSize      int    `json:"attachment_size"`
-	CreatedAt           string `json:"created_at"`
-	UpdatedAt           string `json:"updated_at"`
-	AttachmentHumanSize string `json:"attachment_human_size"`
-	DownloadPath        string `json:"download_path"`
+	ID                  int       `json:"id"`
+	MessageID           int       `json:"message_id"`
+	Filename            string    `json:"filename"`
+	AttachmentType      string    `json:"attachment_type"`
+	ContentType         string    `json:"content_type"`
+	ContentID           string    `json:"content_id"`
+	TransferEncoding    string    `json:"transfer_encoding"`
+	AttachmentSize      int       `json:"attachment_size"`
+	CreatedAt           Timestamp `json:"created_at"`
+	UpdatedAt           Timestamp `json:"updated_at"`
+	AttachmentHumanSize string    `json:"attachment_human_size"`
+	DownloadPath        string    `json:"download_path"`
 }
 
 // List returns message attachments by inboxID and messageID.
@@ -73,3 +84,190 @@ func (s *AttachmentsService) Get(
 
 	return attach, res, err
 }
+
+// Content returns the attachment content as a stream, without buffering it in memory.
+// The caller must close the returned content.
+func (s *AttachmentsService) Content(
+	accountID, inboxID, messageID, attachmentID int,
+) (io.ReadCloser, *Response, error) {
+	u := fmt.Sprintf(
+		"/accounts/%d/inboxes/%d/messages/%d/attachments/%d/download",
+		accountID, inboxID, messageID, attachmentID,
+	)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	return s.client.doStream(req)
+}
+
+// WriteContentTo copies the attachment content to w and returns the number of written bytes.
+func (s *AttachmentsService) WriteContentTo(
+	w io.Writer,
+	accountID, inboxID, messageID, attachmentID int,
+) (int64, *Response, error) {
+	content, res, err := s.Content(accountID, inboxID, messageID, attachmentID)
+	if err != nil {
+		return 0, res, err
+	}
+	defer content.Close()
+
+	n, err := io.Copy(w, content)
+	return n, res, err
+}
+
+// ErrAttachmentSize is returned when the downloaded attachment size differs from its metadata.
+var ErrAttachmentSize = errors.New("attachment size mismatch")
+
+// AttachmentDownload represents the attachment content being downloaded.
+// Reading returns ErrAttachmentSize instead of io.EOF if the content is
+// not of the size announced by the attachment metadata.
+type AttachmentDownload struct {
+	io.ReadCloser
+
+	Attachment *Attachment
+	// ContentType is the content type of the attachment.
+	ContentType string
+	// Size is the attachment size in bytes.
+	Size int64
+}
+
+// Download returns the attachment content as a stream along with its metadata.
+// The size and content type of the response are checked against the metadata.
+// The caller must close the returned download.
+func (s *AttachmentsService) Download(
+	accountID, inboxID, messageID, attachmentID int,
+) (*AttachmentDownload, *Response, error) {
+	attach, res, err := s.Get(accountID, inboxID, messageID, attachmentID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	content, res, err := s.Content(accountID, inboxID, messageID, attachmentID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	size := int64(attach.AttachmentSize)
+	if res.ContentLength >= 0 && res.ContentLength != size {
+		content.Close()
+		return nil, res, fmt.Errorf("%w: got %d bytes, want %d", ErrAttachmentSize, res.ContentLength, size)
+	}
+	if err := checkAttachmentContentType(res.Header.Get("Content-Type"), attach.ContentType); err != nil {
+		content.Close()
+		return nil, res, err
+	}
+
+	download := &AttachmentDownload{
+		ReadCloser:  &sizeCheckReader{ReadCloser: content, want: size},
+		Attachment:  attach,
+		ContentType: attach.ContentType,
+		Size:        size,
+	}
+
+	return download, res, nil
+}
+
+// checkAttachmentContentType compares the media types, ignoring generic binary responses.
+func checkAttachmentContentType(got, want string) error {
+	gotType, _, err := mime.ParseMediaType(got)
+	if err != nil || gotType == "application/octet-stream" || want == "" {
+		return nil
+	}
+	wantType, _, err := mime.ParseMediaType(want)
+	if err != nil {
+		return nil
+	}
+	if gotType != wantType {
+		return fmt.Errorf("attachment content type mismatch: got %q, want %q", gotType, wantType)
+	}
+	return nil
+}
+
+// sizeCheckReader fails the read at EOF if the number of read bytes differs from want.
+type sizeCheckReader struct {
+	io.ReadCloser
+	want int64
+	read int64
+}
+
+func (r *sizeCheckReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.read += int64(n)
+	if r.read > r.want || (err == io.EOF && r.read != r.want) {
+		return n, fmt.Errorf("%w: got %d bytes, want %d", ErrAttachmentSize, r.read, r.want)
+	}
+	return n, err
+}
+
+// SaveAll downloads all attachments of the message into the directory and returns
+// the paths of the saved files. The files are named after the attachments,
+// with a numeric suffix added to duplicate names. Existing files are overwritten.
+func (s *AttachmentsService) SaveAll(accountID, inboxID, messageID int, dir string) ([]string, error) {
+	attachments, _, err := s.List(accountID, inboxID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		paths []string
+		used  = map[string]bool{}
+	)
+	for _, a := range attachments {
+		name := uniqueFilename(attachmentFilename(a), used)
+		path := filepath.Join(dir, name)
+		if err := s.save(accountID, inboxID, messageID, a.ID, path); err != nil {
+			return paths, fmt.Errorf("save attachment %d: %w", a.ID, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// save downloads the attachment into a temporary file renamed to path once complete.
+func (s *AttachmentsService) save(accountID, inboxID, messageID, attachmentID int, path string) error {
+	download, _, err := s.Download(accountID, inboxID, messageID, attachmentID)
+	if err != nil {
+		return err
+	}
+	defer download.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".attachment-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, download); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// attachmentFilename returns the base name of the attachment file, safe to use in a directory.
+func attachmentFilename(a *Attachment) string {
+	name := filepath.Base(strings.ReplaceAll(a.Filename, "\\", "/"))
+	if name == "." || name == "/" || name == ".." || name == "" {
+		name = fmt.Sprintf("attachment-%d", a.ID)
+	}
+	return name
+}
+
+func uniqueFilename(name string, used map[string]bool) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	unique := name
+	for i := 1; used[strings.ToLower(unique)]; i++ {
+		unique = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	used[strings.ToLower(unique)] = true
+	return unique
+}
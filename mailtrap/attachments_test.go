@@ -1,10 +1,16 @@
 package mailtrap
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -113,7 +119,49 @@ func TestAttachmentsService_Get_notFound(t *testing.T) {
 	t.Skip()
 }
 
+func TestAttachmentsService_Content(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments/4/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "*/*")
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, "id,name\n1,John\n")
+	})
+
+	content, _, err := client.Attachments.Content(1, 2, 3, 4)
+	if err != nil {
+		t.Fatalf("Attachments.Content returned error: %v", err)
+	}
+	data, err := ioutil.ReadAll(content)
+	content.Close()
+	if err != nil || string(data) != "id,name\n1,John\n" {
+		t.Errorf("Attachments.Content returned %q, %v", data, err)
+	}
+
+	var buf bytes.Buffer
+	n, _, err := client.Attachments.WriteContentTo(&buf, 1, 2, 3, 4)
+	if err != nil {
+		t.Errorf("Attachments.WriteContentTo returned error: %v", err)
+	}
+	if n != int64(buf.Len()) || buf.String() != "id,name\n1,John\n" {
+		t.Errorf("Attachments.WriteContentTo wrote %d bytes %q", n, buf.String())
+	}
+
+	_, _, err = client.Attachments.Content(1, 2, 3, 5)
+	if err == nil {
+		t.Error("Attachments.Content of missing attachment, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "Attachments.WriteContentTo", &client.client, func() (*Response, error) {
+		_, resp, err := client.Attachments.WriteContentTo(&buf, 1, 2, 3, 4)
+		return resp, err
+	})
+}
+
 func attachment(ID int) *Attachment {
+	datetime, _ := ParseTimestamp("2023-02-13T21:05:55.687Z")
 	return &Attachment{
 		ID:                  ID,
 		MessageID:           2,
@@ -123,9 +171,134 @@ func attachment(ID int) *Attachment {
 		ContentID:           "",
 		TransferEncoding:    "",
 		AttachmentSize:      0,
-		CreatedAt:           "2023-02-13T21:05:55.687Z",
-		UpdatedAt:           "2023-02-13T21:05:55.687Z",
+		CreatedAt:           datetime,
+		UpdatedAt:           datetime,
 		AttachmentHumanSize: "0 Bytes",
 		DownloadPath:        "/api/accounts/1/inboxes/2/messages/3/attachments/4/download",
 	}
 }
+
+func TestAttachmentsService_Download(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	body := "id,name\n1,John\n"
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		a := attachment(4)
+		a.ContentType = "text/csv"
+		a.AttachmentSize = len(body)
+		switch r.URL.Path {
+		case "/accounts/1/inboxes/2/messages/3/attachments/4":
+		case "/accounts/1/inboxes/2/messages/3/attachments/5":
+			a.AttachmentSize = 100
+		case "/accounts/1/inboxes/2/messages/3/attachments/6":
+			a.ContentType = "image/png"
+		case "/accounts/1/inboxes/2/messages/3/attachments/7":
+			a.AttachmentSize = len(body) + 1
+		default:
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			if strings.HasSuffix(r.URL.Path, "/7/download") {
+				w.(http.Flusher).Flush() // chunked response, without Content-Length
+			}
+			fmt.Fprint(w, body)
+			return
+		}
+		resp, _ := json.Marshal(a)
+		fmt.Fprint(w, string(resp))
+	})
+
+	download, _, err := client.Attachments.Download(1, 2, 3, 4)
+	if err != nil {
+		t.Fatalf("Attachments.Download returned error: %v", err)
+	}
+	data, err := ioutil.ReadAll(download)
+	download.Close()
+	if err != nil || string(data) != body {
+		t.Errorf("Attachments.Download returned %q, %v", data, err)
+	}
+	if download.Size != int64(len(body)) || download.ContentType != "text/csv" || download.Attachment.ID != 4 {
+		t.Errorf("Attachments.Download returned %+v", download)
+	}
+
+	if _, _, err := client.Attachments.Download(1, 2, 3, 5); !errors.Is(err, ErrAttachmentSize) {
+		t.Errorf("Attachments.Download with wrong size, err = %v, want %v", err, ErrAttachmentSize)
+	}
+	if _, _, err := client.Attachments.Download(1, 2, 3, 6); err == nil {
+		t.Error("Attachments.Download with wrong content type, err = nil, want error")
+	}
+
+	download, _, err = client.Attachments.Download(1, 2, 3, 7)
+	if err != nil {
+		t.Fatalf("Attachments.Download returned error: %v", err)
+	}
+	_, err = ioutil.ReadAll(download)
+	download.Close()
+	if !errors.Is(err, ErrAttachmentSize) {
+		t.Errorf("Attachments.Download of truncated stream, err = %v, want %v", err, ErrAttachmentSize)
+	}
+
+	testNewRequestAndDoFail(t, "Attachments.Download", &client.client, func() (*Response, error) {
+		download, resp, err := client.Attachments.Download(1, 2, 3, 4)
+		if download != nil {
+			t.Errorf("Attachments.Download client.BaseURL.Host=%v download=%#v, want nil", client.baseURL.Host, download)
+		}
+		return resp, err
+	})
+}
+
+func TestAttachmentsService_SaveAll(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	files := map[int]*Attachment{
+		1: {ID: 1, Filename: "report.csv", ContentType: "text/csv", AttachmentSize: 3},
+		2: {ID: 2, Filename: "../../report.csv", ContentType: "text/csv", AttachmentSize: 3},
+		3: {ID: 3, Filename: "", ContentType: "image/png", AttachmentSize: 3},
+	}
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal([]*Attachment{files[1], files[2], files[3]})
+		fmt.Fprint(w, string(resp))
+	})
+	for id, a := range files {
+		a := a
+		mux.HandleFunc(fmt.Sprintf("/accounts/1/inboxes/2/messages/3/attachments/%d", id), func(w http.ResponseWriter, r *http.Request) {
+			resp, _ := json.Marshal(a)
+			fmt.Fprint(w, string(resp))
+		})
+		mux.HandleFunc(fmt.Sprintf("/accounts/1/inboxes/2/messages/3/attachments/%d/download", id), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			fmt.Fprintf(w, "%03d", a.ID)
+		})
+	}
+
+	dir := t.TempDir()
+	paths, err := client.Attachments.SaveAll(1, 2, 3, dir)
+	if err != nil {
+		t.Fatalf("Attachments.SaveAll returned error: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "report.csv"),
+		filepath.Join(dir, "report-1.csv"),
+		filepath.Join(dir, "attachment-3"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Attachments.SaveAll returned %v, want %v", paths, want)
+	}
+	for i, path := range want {
+		data, err := ioutil.ReadFile(path)
+		if err != nil || string(data) != fmt.Sprintf("%03d", i+1) {
+			t.Errorf("saved file %s = %q, %v", path, data, err)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(want) {
+		t.Errorf("Attachments.SaveAll left %d files in the directory, want %d", len(entries), len(want))
+	}
+
+	if _, err := client.Attachments.SaveAll(1, 2, 3, filepath.Join(dir, "missing")); err == nil {
+		t.Error("Attachments.SaveAll into missing directory, err = nil, want error")
+	}
+}
@@ -0,0 +1,191 @@
+package mailtrap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type ContactExportsServiceContract interface {
+	Create(accountID int, filters []*ContactExportFilter) (*ContactExport, *Response, error)
+	Get(accountID, exportID int) (*ContactExport, *Response, error)
+	ExportCSV(accountID int, filters []*ContactExportFilter, w *csv.Writer, opts *ContactExportOptions) (int, error)
+}
+
+type ContactExportsService struct {
+	client *client
+}
+
+var _ ContactExportsServiceContract = &ContactExportsService{}
+
+// ContactExport represents an asynchronous contacts export job.
+type ContactExport struct {
+	ID int `json:"id"`
+	// status can return created, started, finished, failed
+	Status    string    `json:"status"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+	// URL is the link to download the exported CSV file, set once the job is finished.
+	URL string `json:"url,omitempty"`
+}
+
+// ContactExportFilter represents a filter of the exported contacts,
+// e.g. {Name: "list_id", Operator: "equal", Value: []int{1, 2}}.
+type ContactExportFilter struct {
+	Name     string      `json:"name"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+type contactExportRequest struct {
+	Filters []*ContactExportFilter `json:"filters"`
+}
+
+// ContactExportOptions represents the options of the CSV export.
+type ContactExportOptions struct {
+	// PollInterval is the interval between the job status checks. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// Timeout limits the wait for the job. Zero means no limit.
+	Timeout time.Duration
+}
+
+// Create starts a job exporting the contacts matching the filters.
+// The job is processed asynchronously, use Get to check its status.
+func (s *ContactExportsService) Create(accountID int, filters []*ContactExportFilter) (*ContactExport, *Response, error) {
+	if filters == nil {
+		filters = []*ContactExportFilter{}
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/exports", accountID)
+	return s.makeRequest(u, http.MethodPost, &contactExportRequest{Filters: filters})
+}
+
+// Get returns the contacts export job status.
+func (s *ContactExportsService) Get(accountID, exportID int) (*ContactExport, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/exports/%d", accountID, exportID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// ExportCSV exports the contacts matching the filters, waits for the job to finish
+// and streams the exported file into w. It returns the number of written contacts,
+// not counting the header row.
+func (s *ContactExportsService) ExportCSV(
+	accountID int,
+	filters []*ContactExportFilter,
+	w *csv.Writer,
+	opts *ContactExportOptions,
+) (int, error) {
+	if w == nil {
+		return 0, errors.New("export CSV writer is required")
+	}
+	if opts == nil {
+		opts = &ContactExportOptions{}
+	}
+
+	job, _, err := s.Create(accountID, filters)
+	if err != nil {
+		return 0, err
+	}
+
+	err = pollContactJob(opts.PollInterval, opts.Timeout, func() (bool, error) {
+		if job.Status == ContactJobFinished || job.Status == ContactJobFailed {
+			return true, nil
+		}
+		j, _, err := s.Get(accountID, job.ID)
+		if err != nil {
+			return false, err
+		}
+		job = j
+		return job.Status == ContactJobFinished || job.Status == ContactJobFailed, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if job.Status == ContactJobFailed {
+		return 0, fmt.Errorf("export job %d failed", job.ID)
+	}
+	if job.URL == "" {
+		return 0, fmt.Errorf("export job %d has no download URL", job.ID)
+	}
+
+	return s.download(job.URL, w)
+}
+
+// download copies the exported file into w. The file is served from a storage
+// host, so the request is sent without the API credentials.
+func (s *ContactExportsService) download(url string, w *csv.Writer) (int, error) {
+	resp, err := s.client.httpClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return 0, err
+	}
+
+	body := bufio.NewReader(resp.Body)
+	var r io.Reader = body
+	// The export may be served gzip-compressed regardless of the content type.
+	if magic, err := body.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	rows := 0
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		if err := w.Write(record); err != nil {
+			return rows, err
+		}
+		rows++
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return rows, err
+	}
+	if rows > 0 {
+		// Do not count the header row.
+		rows--
+	}
+
+	return rows, nil
+}
+
+func (s *ContactExportsService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*ContactExport, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var job *ContactExport
+	res, err := s.client.Do(req, &job)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return job, res, nil
+}
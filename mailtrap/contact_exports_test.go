@@ -0,0 +1,146 @@
+package mailtrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContactExportsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/exports", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"filters":[{"name":"list_id","operator":"equal","value":[1,2]}]}`)
+		fmt.Fprint(w, `{"id":1,"status":"created","created_at":"2024-01-01T10:00:00Z","updated_at":"2024-01-01T10:00:00Z"}`)
+	})
+
+	filters := []*ContactExportFilter{{Name: "list_id", Operator: "equal", Value: []int{1, 2}}}
+	job, _, err := client.ContactExports.Create(1, filters)
+	if err != nil {
+		t.Errorf("ContactExports.Create returned error: %v", err)
+	}
+
+	ts := NewTimestamp(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	expected := &ContactExport{ID: 1, Status: ContactJobCreated, CreatedAt: ts, UpdatedAt: ts}
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("ContactExports.Create returned %+v, expected %+v", job, expected)
+	}
+
+	testNewRequestAndDoFail(t, "ContactExports.Create", &client.client, func() (*Response, error) {
+		job, resp, err := client.ContactExports.Create(1, filters)
+		if job != nil {
+			t.Errorf("ContactExports.Create client.BaseURL.Host=%v job=%#v, want nil", client.baseURL.Host, job)
+		}
+		return resp, err
+	})
+}
+
+func TestContactExportsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/exports/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":2,"status":"finished","url":"https://example.com/export.csv"}`)
+	})
+
+	job, _, err := client.ContactExports.Get(1, 2)
+	if err != nil {
+		t.Errorf("ContactExports.Get returned error: %v", err)
+	}
+
+	expected := &ContactExport{ID: 2, Status: ContactJobFinished, URL: "https://example.com/export.csv"}
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("ContactExports.Get returned %+v, expected %+v", job, expected)
+	}
+
+	testBadPathParams(t, "ContactExports.Get", func() error {
+		_, _, err = client.ContactExports.Get(1, -1)
+		return err
+	})
+}
+
+func TestContactExportsService_ExportCSV(t *testing.T) {
+	const data = "email,first_name\njohn@example.com,John\njane@example.com,\"Jane, Jr.\"\n"
+
+	tests := []struct {
+		name string
+		body func() []byte
+	}{
+		{"plain", func() []byte { return []byte(data) }},
+		{"gzip", func() []byte {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			gz.Write([]byte(data))
+			gz.Close()
+			return buf.Bytes()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, teardown := setupTestingClient()
+			defer teardown()
+
+			polls := 0
+			mux.HandleFunc("/accounts/1/contacts/exports", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "POST")
+				testBody(t, r, `{"filters":[]}`)
+				fmt.Fprint(w, `{"id":1,"status":"created"}`)
+			})
+			mux.HandleFunc("/accounts/1/contacts/exports/1", func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				if polls < 2 {
+					fmt.Fprint(w, `{"id":1,"status":"started"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"id":1,"status":"finished","url":"%s/files/export.csv"}`, client.baseURL)
+			})
+			mux.HandleFunc("/files/export.csv", func(w http.ResponseWriter, r *http.Request) {
+				if auth := r.Header.Get("Authorization"); auth != "" {
+					t.Errorf("download Authorization = %q, want empty", auth)
+				}
+				w.Write(tt.body())
+			})
+
+			var buf bytes.Buffer
+			n, err := client.ContactExports.ExportCSV(1, nil, csv.NewWriter(&buf), &ContactExportOptions{PollInterval: time.Millisecond})
+			if err != nil {
+				t.Fatalf("ContactExports.ExportCSV returned error: %v", err)
+			}
+			if n != 2 {
+				t.Errorf("ContactExports.ExportCSV returned %d rows, want 2", n)
+			}
+			if buf.String() != data {
+				t.Errorf("ContactExports.ExportCSV wrote %q, want %q", buf.String(), data)
+			}
+		})
+	}
+}
+
+func TestContactExportsService_ExportCSVFailed(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/exports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"status":"failed"}`)
+	})
+
+	var buf bytes.Buffer
+	_, err := client.ContactExports.ExportCSV(1, nil, csv.NewWriter(&buf), &ContactExportOptions{PollInterval: time.Millisecond})
+	if err == nil {
+		t.Error("ContactExports.ExportCSV err = nil, want error")
+	}
+
+	_, err = client.ContactExports.ExportCSV(1, nil, nil, nil)
+	if err == nil {
+		t.Error("ContactExports.ExportCSV without writer, err = nil, want error")
+	}
+}
@@ -0,0 +1,311 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ContactFieldsServiceContract interface {
+	List(accountID int) ([]*ContactField, *Response, error)
+	Get(accountID, fieldID int) (*ContactField, *Response, error)
+	Create(accountID int, createReq *CreateContactFieldRequest) (*ContactField, *Response, error)
+	Update(accountID, fieldID int, updateReq *UpdateContactFieldRequest) (*ContactField, *Response, error)
+	Delete(accountID, fieldID int) (*Response, error)
+	Schema(accountID int) (ContactFieldSchema, *Response, error)
+}
+
+type ContactFieldsService struct {
+	client *client
+}
+
+var _ ContactFieldsServiceContract = &ContactFieldsService{}
+
+// ContactFieldType is the data type of a custom contact field.
+type ContactFieldType string
+
+const (
+	ContactFieldText    ContactFieldType = "text"
+	ContactFieldInteger ContactFieldType = "integer"
+	ContactFieldFloat   ContactFieldType = "float"
+	ContactFieldBoolean ContactFieldType = "boolean"
+	ContactFieldDate    ContactFieldType = "date"
+)
+
+// contactFieldDateLayout is the format of the date field values.
+const contactFieldDateLayout = "2006-01-02"
+
+// ContactField represents a custom contact field.
+type ContactField struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// MergeTag is the key of the field in the contact fields.
+	MergeTag string           `json:"merge_tag"`
+	DataType ContactFieldType `json:"data_type"`
+}
+
+// CreateContactFieldRequest represents the request to create contact field.
+type CreateContactFieldRequest struct {
+	Name     string           `json:"name"`
+	MergeTag string           `json:"merge_tag"`
+	DataType ContactFieldType `json:"data_type"`
+}
+
+// UpdateContactFieldRequest represents the request to update contact field.
+// The data type of the field can not be changed.
+type UpdateContactFieldRequest struct {
+	Name     string `json:"name,omitempty"`
+	MergeTag string `json:"merge_tag,omitempty"`
+}
+
+// List returns the custom contact fields of the account.
+func (s *ContactFieldsService) List(accountID int) ([]*ContactField, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/fields", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fields []*ContactField
+	res, err := s.client.Do(req, &fields)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return fields, res, nil
+}
+
+// Get returns the custom contact field by ID.
+func (s *ContactFieldsService) Get(accountID, fieldID int) (*ContactField, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/fields/%d", accountID, fieldID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates a custom contact field.
+func (s *ContactFieldsService) Create(
+	accountID int,
+	createReq *CreateContactFieldRequest,
+) (*ContactField, *Response, error) {
+	if createReq == nil || createReq.Name == "" || createReq.MergeTag == "" {
+		return nil, nil, errors.New("contact field 'name' and 'merge_tag' are required")
+	}
+	if !createReq.DataType.valid() {
+		return nil, nil, fmt.Errorf("invalid contact field data type %q", createReq.DataType)
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/fields", accountID)
+	return s.makeRequest(u, http.MethodPost, createReq)
+}
+
+// Update updates the custom contact field name or merge tag.
+func (s *ContactFieldsService) Update(
+	accountID, fieldID int,
+	updateReq *UpdateContactFieldRequest,
+) (*ContactField, *Response, error) {
+	if updateReq == nil || (updateReq.Name == "" && updateReq.MergeTag == "") {
+		return nil, nil, errors.New("contact field 'name' or 'merge_tag' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/fields/%d", accountID, fieldID)
+	return s.makeRequest(u, http.MethodPatch, updateReq)
+}
+
+// Delete removes the custom contact field and its values of all contacts.
+func (s *ContactFieldsService) Delete(accountID, fieldID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/fields/%d", accountID, fieldID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// Schema returns the schema of the custom contact fields of the account.
+func (s *ContactFieldsService) Schema(accountID int) (ContactFieldSchema, *Response, error) {
+	fields, res, err := s.List(accountID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return NewContactFieldSchema(fields), res, nil
+}
+
+func (s *ContactFieldsService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*ContactField, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var field *ContactField
+	res, err := s.client.Do(req, &field)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return field, res, nil
+}
+
+func (t ContactFieldType) valid() bool {
+	switch t {
+	case ContactFieldText, ContactFieldInteger, ContactFieldFloat, ContactFieldBoolean, ContactFieldDate:
+		return true
+	}
+	return false
+}
+
+// Coerce converts the value to the representation of the field type:
+// string for text, int64 for integer, float64 for float, bool for boolean
+// and "YYYY-MM-DD" string for date. Numeric and boolean strings, whole floats
+// for integers and time.Time or Timestamp for dates are accepted. A nil value is kept as is
+// and clears the field.
+func (t ContactFieldType) Coerce(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t {
+	case ContactFieldText:
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case fmt.Stringer:
+			return s.String(), nil
+		}
+	case ContactFieldInteger:
+		if i, ok := toInt64(v); ok {
+			return i, nil
+		}
+	case ContactFieldFloat:
+		if f, ok := toFloat64(v); ok {
+			return f, nil
+		}
+	case ContactFieldBoolean:
+		switch b := v.(type) {
+		case bool:
+			return b, nil
+		case string:
+			if parsed, err := strconv.ParseBool(strings.TrimSpace(b)); err == nil {
+				return parsed, nil
+			}
+		}
+	case ContactFieldDate:
+		switch d := v.(type) {
+		case time.Time:
+			return d.Format(contactFieldDateLayout), nil
+		case *time.Time:
+			if d != nil {
+				return d.Format(contactFieldDateLayout), nil
+			}
+		case Timestamp:
+			return d.Format(contactFieldDateLayout), nil
+		case string:
+			s := strings.TrimSpace(d)
+			if parsed, err := time.Parse(contactFieldDateLayout, s); err == nil {
+				return parsed.Format(contactFieldDateLayout), nil
+			}
+			if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+				return parsed.Format(contactFieldDateLayout), nil
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid contact field data type %q", t)
+	}
+
+	return nil, fmt.Errorf("value %v (%T) is not a valid %s", v, v, t)
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+		return i, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		return int64(u), u <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		// math.MaxInt64 rounds up to 2^63 as float64, so the upper bound is exclusive.
+		if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return 0, false
+}
+
+// ContactFieldSchema maps the merge tags of the custom contact fields to their data types.
+type ContactFieldSchema map[string]ContactFieldType
+
+// NewContactFieldSchema returns the schema of the fields.
+func NewContactFieldSchema(fields []*ContactField) ContactFieldSchema {
+	schema := make(ContactFieldSchema, len(fields))
+	for _, f := range fields {
+		schema[f.MergeTag] = f.DataType
+	}
+	return schema
+}
+
+// Coerce returns a copy of the contact fields with the values converted to
+// the declared types. Unknown merge tags and invalid values are reported as errors.
+func (s ContactFieldSchema) Coerce(fields map[string]interface{}) (map[string]interface{}, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	coerced := make(map[string]interface{}, len(fields))
+	for tag, v := range fields {
+		t, ok := s[tag]
+		if !ok {
+			return nil, fmt.Errorf("unknown contact field %q", tag)
+		}
+		c, err := t.Coerce(v)
+		if err != nil {
+			return nil, fmt.Errorf("contact field %q: %w", tag, err)
+		}
+		coerced[tag] = c
+	}
+
+	return coerced, nil
+}
@@ -0,0 +1,276 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContactFieldsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &ContactField{}, "{}")
+
+	want := `{"id":1,"name":"Zip code","merge_tag":"zip_code","data_type":"integer"}`
+	testJSONMarshal(t, contactFieldMock(1), want)
+}
+
+func TestContactFieldsService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedFields := []*ContactField{contactFieldMock(1), {ID: 2, Name: "Birthday", MergeTag: "birthday", DataType: ContactFieldDate}}
+
+	mux.HandleFunc("/accounts/1/contacts/fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedFields)
+		fmt.Fprint(w, string(resp))
+	})
+
+	fields, _, err := client.ContactFields.List(1)
+	if err != nil {
+		t.Errorf("ContactFields.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("ContactFields.List returned %+v, expected %+v", fields, expectedFields)
+	}
+
+	schema, _, err := client.ContactFields.Schema(1)
+	if err != nil {
+		t.Errorf("ContactFields.Schema returned error: %v", err)
+	}
+
+	expectedSchema := ContactFieldSchema{"zip_code": ContactFieldInteger, "birthday": ContactFieldDate}
+	if !reflect.DeepEqual(schema, expectedSchema) {
+		t.Errorf("ContactFields.Schema returned %+v, expected %+v", schema, expectedSchema)
+	}
+
+	testBadPathParams(t, "ContactFields.List", func() error {
+		_, _, err = client.ContactFields.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "ContactFields.List", &client.client, func() (*Response, error) {
+		fields, resp, err := client.ContactFields.List(1)
+		if fields != nil {
+			t.Errorf("ContactFields.List client.BaseURL.Host=%v fields=%#v, want nil", client.baseURL.Host, fields)
+		}
+		return resp, err
+	})
+}
+
+func TestContactFieldsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/fields/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(contactFieldMock(1))
+		fmt.Fprint(w, string(resp))
+	})
+
+	field, _, err := client.ContactFields.Get(1, 1)
+	if err != nil {
+		t.Errorf("ContactFields.Get returned error: %v", err)
+	}
+
+	if expected := contactFieldMock(1); !reflect.DeepEqual(field, expected) {
+		t.Errorf("ContactFields.Get returned %+v, expected %+v", field, expected)
+	}
+}
+
+func TestContactFieldsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/fields", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"Zip code","merge_tag":"zip_code","data_type":"integer"}`)
+		resp, _ := json.Marshal(contactFieldMock(1))
+		fmt.Fprint(w, string(resp))
+	})
+
+	createReq := &CreateContactFieldRequest{Name: "Zip code", MergeTag: "zip_code", DataType: ContactFieldInteger}
+	field, _, err := client.ContactFields.Create(1, createReq)
+	if err != nil {
+		t.Errorf("ContactFields.Create returned error: %v", err)
+	}
+
+	if expected := contactFieldMock(1); !reflect.DeepEqual(field, expected) {
+		t.Errorf("ContactFields.Create returned %+v, expected %+v", field, expected)
+	}
+
+	_, _, err = client.ContactFields.Create(1, &CreateContactFieldRequest{Name: "Zip code"})
+	if err == nil {
+		t.Error("ContactFields.Create without merge tag, err = nil, want error")
+	}
+
+	_, _, err = client.ContactFields.Create(1, &CreateContactFieldRequest{Name: "Zip code", MergeTag: "zip_code", DataType: "number"})
+	if err == nil {
+		t.Error("ContactFields.Create with invalid data type, err = nil, want error")
+	}
+}
+
+func TestContactFieldsService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/fields/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"name":"Postal code"}`)
+		fmt.Fprint(w, `{"id":1,"name":"Postal code","merge_tag":"zip_code","data_type":"integer"}`)
+	})
+
+	field, _, err := client.ContactFields.Update(1, 1, &UpdateContactFieldRequest{Name: "Postal code"})
+	if err != nil {
+		t.Errorf("ContactFields.Update returned error: %v", err)
+	}
+
+	expected := &ContactField{ID: 1, Name: "Postal code", MergeTag: "zip_code", DataType: ContactFieldInteger}
+	if !reflect.DeepEqual(field, expected) {
+		t.Errorf("ContactFields.Update returned %+v, expected %+v", field, expected)
+	}
+
+	_, _, err = client.ContactFields.Update(1, 1, &UpdateContactFieldRequest{})
+	if err == nil {
+		t.Error("ContactFields.Update without changes, err = nil, want error")
+	}
+}
+
+func TestContactFieldsService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/fields/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.ContactFields.Delete(1, 1)
+	if err != nil {
+		t.Errorf("ContactFields.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "ContactFields.Delete", &client.client, func() (*Response, error) {
+		return client.ContactFields.Delete(1, 1)
+	})
+}
+
+func TestContactFieldType_Coerce(t *testing.T) {
+	date := time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		typ     ContactFieldType
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{ContactFieldText, "John", "John", false},
+		{ContactFieldText, 42, nil, true},
+		{ContactFieldText, nil, nil, false},
+		{ContactFieldInteger, 42, int64(42), false},
+		{ContactFieldInteger, uint8(7), int64(7), false},
+		{ContactFieldInteger, float64(11111), int64(11111), false},
+		{ContactFieldInteger, " 12 ", int64(12), false},
+		{ContactFieldInteger, json.Number("5"), int64(5), false},
+		{ContactFieldInteger, 1.5, nil, true},
+		{ContactFieldInteger, float64(1 << 63), nil, true},
+		{ContactFieldInteger, -float64(1 << 63), int64(math.MinInt64), false},
+		{ContactFieldInteger, math.Inf(1), nil, true},
+		{ContactFieldInteger, "abc", nil, true},
+		{ContactFieldInteger, true, nil, true},
+		{ContactFieldFloat, 1.5, 1.5, false},
+		{ContactFieldFloat, 2, float64(2), false},
+		{ContactFieldFloat, "3.25", 3.25, false},
+		{ContactFieldFloat, "NaN", nil, true},
+		{ContactFieldBoolean, true, true, false},
+		{ContactFieldBoolean, "false", false, false},
+		{ContactFieldBoolean, "yes", nil, true},
+		{ContactFieldBoolean, 1, nil, true},
+		{ContactFieldDate, date, "2024-03-01", false},
+		{ContactFieldDate, &date, "2024-03-01", false},
+		{ContactFieldDate, NewTimestamp(date), "2024-03-01", false},
+		{ContactFieldDate, "2024-03-01", "2024-03-01", false},
+		{ContactFieldDate, "2024-03-01T10:00:00Z", "2024-03-01", false},
+		{ContactFieldDate, "01/03/2024", nil, true},
+		{"number", 1, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.typ.Coerce(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s.Coerce(%#v) error = %v, wantErr %v", tt.typ, tt.value, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s.Coerce(%#v) = %#v, want %#v", tt.typ, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestContactFieldSchema_Coerce(t *testing.T) {
+	schema := ContactFieldSchema{"zip_code": ContactFieldInteger, "vip": ContactFieldBoolean}
+
+	fields := map[string]interface{}{"zip_code": "11111", "vip": true}
+	got, err := schema.Coerce(fields)
+	if err != nil {
+		t.Fatalf("ContactFieldSchema.Coerce returned error: %v", err)
+	}
+	want := map[string]interface{}{"zip_code": int64(11111), "vip": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ContactFieldSchema.Coerce = %#v, want %#v", got, want)
+	}
+	if fields["zip_code"] != "11111" {
+		t.Error("ContactFieldSchema.Coerce modified the input fields")
+	}
+
+	if _, err := schema.Coerce(map[string]interface{}{"unknown": 1}); err == nil {
+		t.Error("ContactFieldSchema.Coerce with unknown field, err = nil, want error")
+	}
+	if _, err := schema.Coerce(map[string]interface{}{"zip_code": "abc"}); err == nil {
+		t.Error("ContactFieldSchema.Coerce with invalid value, err = nil, want error")
+	}
+}
+
+func TestContactsService_Schema(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, `{"contact":{"email":"john@example.com","fields":{"zip_code":11111}}}`)
+		fmt.Fprint(w, `{"data":{"id":"1","email":"john@example.com"}}`)
+	})
+	mux.HandleFunc("/accounts/1/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, `{"contact":{"fields":{"zip_code":22222}}}`)
+		fmt.Fprint(w, `{"data":{"id":"1","email":"john@example.com"}}`)
+	})
+
+	client.Contacts.Schema = ContactFieldSchema{"zip_code": ContactFieldInteger}
+
+	createReq := &CreateContactRequest{Email: "john@example.com", Fields: map[string]interface{}{"zip_code": "11111"}}
+	if _, _, err := client.Contacts.Create(1, createReq); err != nil {
+		t.Errorf("Contacts.Create returned error: %v", err)
+	}
+
+	updateReq := &UpdateContactRequest{Fields: map[string]interface{}{"zip_code": 22222.0}}
+	if _, _, err := client.Contacts.Update(1, "1", updateReq); err != nil {
+		t.Errorf("Contacts.Update returned error: %v", err)
+	}
+
+	invalidReq := &CreateContactRequest{Email: "john@example.com", Fields: map[string]interface{}{"zip_code": "abc"}}
+	if _, _, err := client.Contacts.Create(1, invalidReq); err == nil {
+		t.Error("Contacts.Create with invalid field, err = nil, want error")
+	}
+}
+
+func contactFieldMock(ID int) *ContactField {
+	return &ContactField{
+		ID:       ID,
+		Name:     "Zip code",
+		MergeTag: "zip_code",
+		DataType: ContactFieldInteger,
+	}
+}
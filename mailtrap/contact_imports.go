@@ -0,0 +1,273 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"time"
+)
+
+type ContactImportsServiceContract interface {
+	Create(accountID int, contacts []*ContactImportRow) (*ContactImport, *Response, error)
+	Get(accountID, importID int) (*ContactImport, *Response, error)
+	Import(accountID int, contacts []*ContactImportRow, opts *ContactImportOptions) (*ContactImportResult, error)
+}
+
+type ContactImportsService struct {
+	client *client
+}
+
+var _ ContactImportsServiceContract = &ContactImportsService{}
+
+// Contact import and export job statuses.
+const (
+	ContactJobCreated  = "created"
+	ContactJobStarted  = "started"
+	ContactJobFinished = "finished"
+	ContactJobFailed   = "failed"
+)
+
+const (
+	// maxContactImportChunkSize is the maximum number of contacts per import request.
+	maxContactImportChunkSize = 50000
+
+	defaultContactImportChunkSize = 1000
+	defaultContactJobPollInterval = 2 * time.Second
+)
+
+// ContactImport represents an asynchronous contacts import job.
+type ContactImport struct {
+	ID int `json:"id"`
+	// status can return created, started, finished, failed
+	Status                 string `json:"status"`
+	CreatedContactsCount   int    `json:"created_contacts_count"`
+	UpdatedContactsCount   int    `json:"updated_contacts_count"`
+	ContactsOverLimitCount int    `json:"contacts_over_limit_count"`
+}
+
+// ContactImportRow represents a contact to import.
+// Existing contacts with the same email are updated.
+type ContactImportRow struct {
+	Email           string                 `json:"email"`
+	Fields          map[string]interface{} `json:"fields,omitempty"`
+	ListIDsIncluded []int                  `json:"list_ids_included,omitempty"`
+	ListIDsExcluded []int                  `json:"list_ids_excluded,omitempty"`
+}
+
+type contactImportRequest struct {
+	Contacts []*ContactImportRow `json:"contacts"`
+}
+
+// ContactImportOptions represents the options of the bulk import.
+type ContactImportOptions struct {
+	// ChunkSize is the number of contacts per import job. Defaults to 1000, at most 50000.
+	ChunkSize int
+	// PollInterval is the interval between the job status checks. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// Timeout limits the wait for a single job. Zero means no limit.
+	Timeout time.Duration
+	// Progress is called after each chunk with the number of processed and total rows. Optional.
+	Progress func(processed, total int)
+}
+
+// ContactImportRowError represents a contact which has not been imported.
+type ContactImportRowError struct {
+	// Row is the index of the contact in the imported slice.
+	Row   int
+	Email string
+	Err   error
+}
+
+func (e *ContactImportRowError) Error() string {
+	return fmt.Sprintf("row %d (%s): %v", e.Row, e.Email, e.Err)
+}
+
+// ContactImportResult represents the summary of the bulk import.
+type ContactImportResult struct {
+	Jobs      []*ContactImport
+	Created   int
+	Updated   int
+	OverLimit int
+	Errors    []*ContactImportRowError
+}
+
+// Create starts a job importing the contacts. The job is processed asynchronously,
+// use Get to check its status.
+func (s *ContactImportsService) Create(accountID int, contacts []*ContactImportRow) (*ContactImport, *Response, error) {
+	if len(contacts) == 0 {
+		return nil, nil, errors.New("import 'contacts' are required")
+	}
+	if len(contacts) > maxContactImportChunkSize {
+		return nil, nil, fmt.Errorf("import 'contacts' exceed %d rows", maxContactImportChunkSize)
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/imports", accountID)
+	return s.makeRequest(u, http.MethodPost, &contactImportRequest{Contacts: contacts})
+}
+
+// Get returns the contacts import job status.
+func (s *ContactImportsService) Get(accountID, importID int) (*ContactImport, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/imports/%d", accountID, importID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Import uploads the contacts in chunks and waits for each import job to finish.
+//
+// Nil contacts, contacts with invalid emails, and contacts of the jobs rejected
+// with a validation error or failed are reported as row errors. Other errors,
+// e.g. authorization, rate limit or server errors, or a job status which could not
+// be retrieved in time, abort the import. The result then covers the chunks processed so far.
+func (s *ContactImportsService) Import(
+	accountID int,
+	contacts []*ContactImportRow,
+	opts *ContactImportOptions,
+) (*ContactImportResult, error) {
+	if opts == nil {
+		opts = &ContactImportOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultContactImportChunkSize
+	}
+	if chunkSize > maxContactImportChunkSize {
+		chunkSize = maxContactImportChunkSize
+	}
+
+	result := &ContactImportResult{}
+
+	var (
+		rows    []*ContactImportRow
+		indexes []int
+	)
+	for i, c := range contacts {
+		if c == nil {
+			result.Errors = append(result.Errors, &ContactImportRowError{Row: i, Err: errors.New("contact is nil")})
+			continue
+		}
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			result.Errors = append(result.Errors, &ContactImportRowError{Row: i, Email: c.Email, Err: errors.New("invalid email")})
+			continue
+		}
+		rows = append(rows, c)
+		indexes = append(indexes, i)
+	}
+
+	processed := len(contacts) - len(rows)
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		job, err := s.importChunk(accountID, rows[start:end], opts)
+		if job != nil {
+			result.Jobs = append(result.Jobs, job)
+			result.Created += job.CreatedContactsCount
+			result.Updated += job.UpdatedContactsCount
+			result.OverLimit += job.ContactsOverLimitCount
+		}
+		if err != nil {
+			var jobErr *contactImportJobError
+			if !errors.As(err, &jobErr) {
+				return result, err
+			}
+			for i := start; i < end; i++ {
+				result.Errors = append(result.Errors, &ContactImportRowError{Row: indexes[i], Email: rows[i].Email, Err: jobErr.err})
+			}
+		}
+
+		processed += end - start
+		if opts.Progress != nil {
+			opts.Progress(processed, len(contacts))
+		}
+	}
+
+	return result, nil
+}
+
+// contactImportJobError is the error of a rejected or failed import job.
+type contactImportJobError struct {
+	err error
+}
+
+func (e *contactImportJobError) Error() string {
+	return e.err.Error()
+}
+
+func (s *ContactImportsService) importChunk(
+	accountID int,
+	rows []*ContactImportRow,
+	opts *ContactImportOptions,
+) (*ContactImport, error) {
+	job, _, err := s.Create(accountID, rows)
+	if err != nil {
+		// Only the validation errors concern the rows, others would fail every chunk.
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusUnprocessableEntity {
+			return nil, &contactImportJobError{err: err}
+		}
+		return nil, err
+	}
+
+	err = pollContactJob(opts.PollInterval, opts.Timeout, func() (bool, error) {
+		if job.Status == ContactJobFinished || job.Status == ContactJobFailed {
+			return true, nil
+		}
+		j, _, err := s.Get(accountID, job.ID)
+		if err != nil {
+			return false, err
+		}
+		job = j
+		return job.Status == ContactJobFinished || job.Status == ContactJobFailed, nil
+	})
+	if err != nil {
+		return job, err
+	}
+	if job.Status == ContactJobFailed {
+		return job, &contactImportJobError{err: fmt.Errorf("import job %d failed", job.ID)}
+	}
+
+	return job, nil
+}
+
+func (s *ContactImportsService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*ContactImport, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var job *ContactImport
+	res, err := s.client.Do(req, &job)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return job, res, nil
+}
+
+// pollContactJob calls check until it reports the job is done.
+func pollContactJob(interval, timeout time.Duration, check func() (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultContactJobPollInterval
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return errors.New("timed out waiting for the contacts job")
+		}
+		time.Sleep(interval)
+	}
+}
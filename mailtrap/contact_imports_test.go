@@ -0,0 +1,225 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContactImportsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/imports", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"contacts":[{"email":"john@example.com","fields":{"first_name":"John"},"list_ids_included":[1]}]}`)
+		fmt.Fprint(w, `{"id":1,"status":"created"}`)
+	})
+
+	rows := []*ContactImportRow{{
+		Email:           "john@example.com",
+		Fields:          map[string]interface{}{"first_name": "John"},
+		ListIDsIncluded: []int{1},
+	}}
+	job, _, err := client.ContactImports.Create(1, rows)
+	if err != nil {
+		t.Errorf("ContactImports.Create returned error: %v", err)
+	}
+
+	expected := &ContactImport{ID: 1, Status: ContactJobCreated}
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("ContactImports.Create returned %+v, expected %+v", job, expected)
+	}
+
+	_, _, err = client.ContactImports.Create(1, nil)
+	if err == nil {
+		t.Error("ContactImports.Create without contacts, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "ContactImports.Create", &client.client, func() (*Response, error) {
+		job, resp, err := client.ContactImports.Create(1, rows)
+		if job != nil {
+			t.Errorf("ContactImports.Create client.BaseURL.Host=%v job=%#v, want nil", client.baseURL.Host, job)
+		}
+		return resp, err
+	})
+}
+
+func TestContactImportsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/imports/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"id": 2,
+			"status": "finished",
+			"created_contacts_count": 3,
+			"updated_contacts_count": 1,
+			"contacts_over_limit_count": 0
+		}`)
+	})
+
+	job, _, err := client.ContactImports.Get(1, 2)
+	if err != nil {
+		t.Errorf("ContactImports.Get returned error: %v", err)
+	}
+
+	expected := &ContactImport{ID: 2, Status: ContactJobFinished, CreatedContactsCount: 3, UpdatedContactsCount: 1}
+	if !reflect.DeepEqual(job, expected) {
+		t.Errorf("ContactImports.Get returned %+v, expected %+v", job, expected)
+	}
+
+	testBadPathParams(t, "ContactImports.Get", func() error {
+		_, _, err = client.ContactImports.Get(1, -1)
+		return err
+	})
+}
+
+func TestContactImportsService_Import(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var (
+		mu     sync.Mutex
+		nextID int
+		chunks = map[int][]*ContactImportRow{}
+		polls  = map[int]int{}
+	)
+	mux.HandleFunc("/accounts/1/contacts/imports", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var req contactImportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode import request: %v", err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Contacts[0].Email == "rejected@example.com" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":["contacts limit exceeded"]}`)
+			return
+		}
+		nextID++
+		chunks[nextID] = req.Contacts
+		fmt.Fprintf(w, `{"id":%d,"status":"created"}`, nextID)
+	})
+	mux.HandleFunc("/accounts/1/contacts/imports/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		var id int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/accounts/1/contacts/imports/"), "%d", &id)
+		mu.Lock()
+		defer mu.Unlock()
+		polls[id]++
+		status := ContactJobStarted
+		if polls[id] > 1 {
+			status = ContactJobFinished
+			if chunks[id][0].Email == "failed@example.com" {
+				status = ContactJobFailed
+			}
+		}
+		fmt.Fprintf(w, `{"id":%d,"status":%q,"created_contacts_count":%d}`, id, status, len(chunks[id]))
+	})
+
+	rows := []*ContactImportRow{
+		{Email: "a@example.com"},
+		{Email: "invalid"},
+		{Email: "b@example.com"},
+		{Email: "rejected@example.com"},
+		{Email: "c@example.com"},
+		{Email: "failed@example.com"},
+		{Email: "d@example.com"},
+	}
+
+	var progress []int
+	opts := &ContactImportOptions{
+		ChunkSize:    2,
+		PollInterval: time.Millisecond,
+		Progress: func(processed, total int) {
+			if total != len(rows) {
+				t.Errorf("Progress total = %d, want %d", total, len(rows))
+			}
+			progress = append(progress, processed)
+		},
+	}
+	result, err := client.ContactImports.Import(1, rows, opts)
+	if err != nil {
+		t.Fatalf("ContactImports.Import returned error: %v", err)
+	}
+
+	// Chunks: [a b] [rejected c] [failed d].
+	if len(result.Jobs) != 2 {
+		t.Errorf("ContactImports.Import jobs = %d, want 2", len(result.Jobs))
+	}
+	if result.Created != 4 {
+		t.Errorf("ContactImports.Import created = %d, want 4", result.Created)
+	}
+
+	var errRows []int
+	for _, e := range result.Errors {
+		errRows = append(errRows, e.Row)
+		if e.Email != rows[e.Row].Email {
+			t.Errorf("row error %d email = %q, want %q", e.Row, e.Email, rows[e.Row].Email)
+		}
+	}
+	if want := []int{1, 3, 4, 5, 6}; !reflect.DeepEqual(errRows, want) {
+		t.Errorf("ContactImports.Import error rows = %v, want %v", errRows, want)
+	}
+	if want := []int{3, 5, 7}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("ContactImports.Import progress = %v, want %v", progress, want)
+	}
+}
+
+func TestContactImportsService_ImportAborted(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/accounts/1/contacts/imports", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":["Unauthorized"]}`)
+	})
+
+	rows := []*ContactImportRow{nil, {Email: "a@example.com"}, {Email: "b@example.com"}}
+	result, err := client.ContactImports.Import(1, rows, &ContactImportOptions{ChunkSize: 1})
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("ContactImports.Import returned error %v, want unauthorized error", err)
+	}
+	if requests != 1 {
+		t.Errorf("ContactImports.Import sent %d requests, want 1", requests)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 0 {
+		t.Errorf("ContactImports.Import row errors = %v, want the nil row only", result.Errors)
+	}
+}
+
+func TestContactImportsService_ImportTimeout(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/imports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"status":"created"}`)
+	})
+	mux.HandleFunc("/accounts/1/contacts/imports/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"status":"started"}`)
+	})
+
+	opts := &ContactImportOptions{PollInterval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond}
+	result, err := client.ContactImports.Import(1, []*ContactImportRow{{Email: "john@example.com"}}, opts)
+	if err == nil {
+		t.Fatal("ContactImports.Import err = nil, want timeout error")
+	}
+	if result == nil || len(result.Jobs) != 1 {
+		t.Errorf("ContactImports.Import result = %+v, want the started job", result)
+	}
+}
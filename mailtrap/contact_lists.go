@@ -0,0 +1,123 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type ContactListsServiceContract interface {
+	List(accountID int) ([]*ContactList, *Response, error)
+	Get(accountID, listID int) (*ContactList, *Response, error)
+	Create(accountID int, name string) (*ContactList, *Response, error)
+	Update(accountID, listID int, name string) (*ContactList, *Response, error)
+	Delete(accountID, listID int) (*Response, error)
+	AddContact(accountID, listID int, contactIDOrEmail string) (*Contact, *Response, error)
+	RemoveContact(accountID, listID int, contactIDOrEmail string) (*Contact, *Response, error)
+}
+
+type ContactListsService struct {
+	client *client
+}
+
+var _ ContactListsServiceContract = &ContactListsService{}
+
+// ContactList represents a Mailtrap contact list.
+type ContactList struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type contactListRequest struct {
+	Name string `json:"name"`
+}
+
+// List returns the contact lists of the account.
+func (s *ContactListsService) List(accountID int) ([]*ContactList, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/lists", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lists []*ContactList
+	res, err := s.client.Do(req, &lists)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return lists, res, nil
+}
+
+// Get returns the contact list by ID.
+func (s *ContactListsService) Get(accountID, listID int) (*ContactList, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/lists/%d", accountID, listID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates a contact list.
+func (s *ContactListsService) Create(accountID int, name string) (*ContactList, *Response, error) {
+	if name == "" {
+		return nil, nil, errors.New("contact list 'name' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/lists", accountID)
+	return s.makeRequest(u, http.MethodPost, &contactListRequest{Name: name})
+}
+
+// Update updates the contact list name.
+func (s *ContactListsService) Update(accountID, listID int, name string) (*ContactList, *Response, error) {
+	if name == "" {
+		return nil, nil, errors.New("contact list 'name' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/lists/%d", accountID, listID)
+	return s.makeRequest(u, http.MethodPatch, &contactListRequest{Name: name})
+}
+
+// Delete removes the contact list. The contacts of the list are kept.
+func (s *ContactListsService) Delete(accountID, listID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/contacts/lists/%d", accountID, listID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// AddContact adds the contact to the list.
+func (s *ContactListsService) AddContact(
+	accountID, listID int,
+	contactIDOrEmail string,
+) (*Contact, *Response, error) {
+	contacts := &ContactsService{client: s.client}
+	return contacts.Update(accountID, contactIDOrEmail, &UpdateContactRequest{ListIDsIncluded: []int{listID}})
+}
+
+// RemoveContact removes the contact from the list. The contact itself is kept.
+func (s *ContactListsService) RemoveContact(
+	accountID, listID int,
+	contactIDOrEmail string,
+) (*Contact, *Response, error) {
+	contacts := &ContactsService{client: s.client}
+	return contacts.Update(accountID, contactIDOrEmail, &UpdateContactRequest{ListIDsExcluded: []int{listID}})
+}
+
+func (s *ContactListsService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*ContactList, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list *ContactList
+	res, err := s.client.Do(req, &list)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return list, res, nil
+}
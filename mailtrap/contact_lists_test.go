@@ -0,0 +1,175 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestContactListsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &ContactList{}, "{}")
+	testJSONMarshal(t, &ContactList{ID: 1, Name: "Customers"}, `{"id":1,"name":"Customers"}`)
+}
+
+func TestContactListsService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedLists := []*ContactList{{ID: 1, Name: "Customers"}, {ID: 2, Name: "Leads"}}
+
+	mux.HandleFunc("/accounts/1/contacts/lists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedLists)
+		fmt.Fprint(w, string(resp))
+	})
+
+	lists, _, err := client.ContactLists.List(1)
+	if err != nil {
+		t.Errorf("ContactLists.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(lists, expectedLists) {
+		t.Errorf("ContactLists.List returned %+v, expected %+v", lists, expectedLists)
+	}
+
+	testBadPathParams(t, "ContactLists.List", func() error {
+		_, _, err = client.ContactLists.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "ContactLists.List", &client.client, func() (*Response, error) {
+		lists, resp, err := client.ContactLists.List(1)
+		if lists != nil {
+			t.Errorf("ContactLists.List client.BaseURL.Host=%v lists=%#v, want nil", client.baseURL.Host, lists)
+		}
+		return resp, err
+	})
+}
+
+func TestContactListsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/lists/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":2,"name":"Leads"}`)
+	})
+
+	list, _, err := client.ContactLists.Get(1, 2)
+	if err != nil {
+		t.Errorf("ContactLists.Get returned error: %v", err)
+	}
+
+	expected := &ContactList{ID: 2, Name: "Leads"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("ContactLists.Get returned %+v, expected %+v", list, expected)
+	}
+
+	testNewRequestAndDoFail(t, "ContactLists.Get", &client.client, func() (*Response, error) {
+		list, resp, err := client.ContactLists.Get(1, 2)
+		if list != nil {
+			t.Errorf("ContactLists.Get client.BaseURL.Host=%v list=%#v, want nil", client.baseURL.Host, list)
+		}
+		return resp, err
+	})
+}
+
+func TestContactListsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/lists", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"name":"Customers"}`)
+		fmt.Fprint(w, `{"id":1,"name":"Customers"}`)
+	})
+
+	list, _, err := client.ContactLists.Create(1, "Customers")
+	if err != nil {
+		t.Errorf("ContactLists.Create returned error: %v", err)
+	}
+
+	expected := &ContactList{ID: 1, Name: "Customers"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("ContactLists.Create returned %+v, expected %+v", list, expected)
+	}
+
+	_, _, err = client.ContactLists.Create(1, "")
+	if err == nil {
+		t.Error("ContactLists.Create without name, err = nil, want error")
+	}
+}
+
+func TestContactListsService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/lists/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"name":"Old customers"}`)
+		fmt.Fprint(w, `{"id":2,"name":"Old customers"}`)
+	})
+
+	list, _, err := client.ContactLists.Update(1, 2, "Old customers")
+	if err != nil {
+		t.Errorf("ContactLists.Update returned error: %v", err)
+	}
+
+	expected := &ContactList{ID: 2, Name: "Old customers"}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("ContactLists.Update returned %+v, expected %+v", list, expected)
+	}
+
+	_, _, err = client.ContactLists.Update(1, 2, "")
+	if err == nil {
+		t.Error("ContactLists.Update without name, err = nil, want error")
+	}
+}
+
+func TestContactListsService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/lists/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.ContactLists.Delete(1, 2)
+	if err != nil {
+		t.Errorf("ContactLists.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "ContactLists.Delete", &client.client, func() (*Response, error) {
+		return client.ContactLists.Delete(1, 2)
+	})
+}
+
+func TestContactListsService_AddRemoveContact(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var wantBody string
+	mux.HandleFunc("/accounts/1/contacts/john@example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, wantBody)
+		fmt.Fprint(w, `{"data":{"id":"1","email":"john@example.com"}}`)
+	})
+
+	wantBody = `{"contact":{"list_ids_included":[2]}}`
+	contact, _, err := client.ContactLists.AddContact(1, 2, "john@example.com")
+	if err != nil {
+		t.Errorf("ContactLists.AddContact returned error: %v", err)
+	}
+	if expected := (&Contact{ID: "1", Email: "john@example.com"}); !reflect.DeepEqual(contact, expected) {
+		t.Errorf("ContactLists.AddContact returned %+v, expected %+v", contact, expected)
+	}
+
+	wantBody = `{"contact":{"list_ids_excluded":[2]}}`
+	_, _, err = client.ContactLists.RemoveContact(1, 2, "john@example.com")
+	if err != nil {
+		t.Errorf("ContactLists.RemoveContact returned error: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type ContactsServiceContract interface {
+	Create(accountID int, createReq *CreateContactRequest) (*Contact, *Response, error)
+	Get(accountID int, contactIDOrEmail string) (*Contact, *Response, error)
+	Update(accountID int, contactIDOrEmail string, updateReq *UpdateContactRequest) (*Contact, *Response, error)
+	Delete(accountID int, contactIDOrEmail string) (*Response, error)
+}
+
+type ContactsService struct {
+	client *client
+
+	// Schema of the custom contact fields. If set, the fields of created and
+	// updated contacts are coerced to the declared types before sending.
+	Schema ContactFieldSchema
+}
+
+var _ ContactsServiceContract = &ContactsService{}
+
+// Contact represents a Mailtrap contact.
+type Contact struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	// Fields contains the custom field values keyed by the field merge tag.
+	Fields  map[string]interface{} `json:"fields"`
+	ListIDs []int                  `json:"list_ids"`
+	// status can return subscribed, unsubscribed
+	Status    string    `json:"status"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// CreateContactRequest represents the request to create contact.
+type CreateContactRequest struct {
+	Email   string                 `json:"email"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	ListIDs []int                  `json:"list_ids,omitempty"`
+}
+
+// UpdateContactRequest represents the request to update contact.
+type UpdateContactRequest struct {
+	Email  string                 `json:"email,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// ListIDsIncluded adds the contact to the lists.
+	ListIDsIncluded []int `json:"list_ids_included,omitempty"`
+	// ListIDsExcluded removes the contact from the lists.
+	ListIDsExcluded []int `json:"list_ids_excluded,omitempty"`
+	Unsubscribed    *bool `json:"unsubscribed,omitempty"`
+}
+
+type contactRequest struct {
+	Contact interface{} `json:"contact"`
+}
+
+type contactResponse struct {
+	Data *Contact `json:"data"`
+}
+
+// Create creates a contact.
+func (s *ContactsService) Create(accountID int, createReq *CreateContactRequest) (*Contact, *Response, error) {
+	if createReq == nil || createReq.Email == "" {
+		return nil, nil, errors.New("contact 'email' is required")
+	}
+
+	if s.Schema != nil {
+		fields, err := s.Schema.Coerce(createReq.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		req := *createReq
+		req.Fields = fields
+		createReq = &req
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts", accountID)
+	return s.makeRequest(u, http.MethodPost, &contactRequest{Contact: createReq})
+}
+
+// Get returns the contact by its ID or email.
+func (s *ContactsService) Get(accountID int, contactIDOrEmail string) (*Contact, *Response, error) {
+	if contactIDOrEmail == "" {
+		return nil, nil, errors.New("contact ID or email is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/%s", accountID, url.PathEscape(contactIDOrEmail))
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Update updates the contact email, fields, list memberships or subscription status.
+func (s *ContactsService) Update(
+	accountID int,
+	contactIDOrEmail string,
+	updateReq *UpdateContactRequest,
+) (*Contact, *Response, error) {
+	if contactIDOrEmail == "" {
+		return nil, nil, errors.New("contact ID or email is required")
+	}
+	if s.Schema != nil && updateReq != nil {
+		fields, err := s.Schema.Coerce(updateReq.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		req := *updateReq
+		req.Fields = fields
+		updateReq = &req
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/%s", accountID, url.PathEscape(contactIDOrEmail))
+	return s.makeRequest(u, http.MethodPatch, &contactRequest{Contact: updateReq})
+}
+
+// Delete removes the contact by its ID or email.
+func (s *ContactsService) Delete(accountID int, contactIDOrEmail string) (*Response, error) {
+	if contactIDOrEmail == "" {
+		return nil, errors.New("contact ID or email is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/contacts/%s", accountID, url.PathEscape(contactIDOrEmail))
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *ContactsService) makeRequest(endpoint, httpMethod string, payload interface{}) (*Contact, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contact contactResponse
+	res, err := s.client.Do(req, &contact)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return contact.Data, res, nil
+}
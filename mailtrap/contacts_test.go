@@ -0,0 +1,183 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContactsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &Contact{}, "{}")
+
+	u := contactMock("018dd5e3-f6d2-7c00-8f9b-e5c3f2d8a132")
+	want := `{
+		"id": "018dd5e3-f6d2-7c00-8f9b-e5c3f2d8a132",
+		"email": "john@example.com",
+		"fields": {
+			"first_name": "John",
+			"zip_code": 11111
+		},
+		"list_ids": [1, 2],
+		"status": "subscribed",
+		"created_at": 1700236800000,
+		"updated_at": 1700236800000
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestContactsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedContact := contactMock("1")
+
+	mux.HandleFunc("/accounts/1/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"contact":{"email":"john@example.com","fields":{"first_name":"John"},"list_ids":[1,2]}}`)
+		resp, _ := json.Marshal(&contactResponse{Data: expectedContact})
+		fmt.Fprint(w, string(resp))
+	})
+
+	createReq := &CreateContactRequest{
+		Email:   "john@example.com",
+		Fields:  map[string]interface{}{"first_name": "John"},
+		ListIDs: []int{1, 2},
+	}
+	contact, _, err := client.Contacts.Create(1, createReq)
+	if err != nil {
+		t.Errorf("Contacts.Create returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(contact, expectedContact) {
+		t.Errorf("Contacts.Create returned %+v, expected %+v", contact, expectedContact)
+	}
+
+	_, _, err = client.Contacts.Create(1, &CreateContactRequest{})
+	if err == nil {
+		t.Error("Contacts.Create without email, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "Contacts.Create", &client.client, func() (*Response, error) {
+		contact, resp, err := client.Contacts.Create(1, createReq)
+		if contact != nil {
+			t.Errorf("Contacts.Create client.BaseURL.Host=%v contact=%#v, want nil", client.baseURL.Host, contact)
+		}
+		return resp, err
+	})
+}
+
+func TestContactsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedContact := contactMock("1")
+
+	mux.HandleFunc("/accounts/1/contacts/john@example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(&contactResponse{Data: expectedContact})
+		fmt.Fprint(w, string(resp))
+	})
+
+	contact, _, err := client.Contacts.Get(1, "john@example.com")
+	if err != nil {
+		t.Errorf("Contacts.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(contact, expectedContact) {
+		t.Errorf("Contacts.Get returned %+v, expected %+v", contact, expectedContact)
+	}
+
+	testBadPathParams(t, "Contacts.Get", func() error {
+		_, _, err = client.Contacts.Get(1, "")
+		return err
+	})
+
+	testBadPathParams(t, "Contacts.Get", func() error {
+		_, _, err = client.Contacts.Get(1, "unknown")
+		return err
+	})
+}
+
+func TestContactsService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"contact":{"list_ids_included":[3],"list_ids_excluded":[1],"unsubscribed":true}}`)
+		fmt.Fprint(w, `{"action":"updated","data":{"id":"1","email":"john@example.com","status":"unsubscribed"}}`)
+	})
+
+	unsubscribed := true
+	updateReq := &UpdateContactRequest{
+		ListIDsIncluded: []int{3},
+		ListIDsExcluded: []int{1},
+		Unsubscribed:    &unsubscribed,
+	}
+	contact, _, err := client.Contacts.Update(1, "1", updateReq)
+	if err != nil {
+		t.Errorf("Contacts.Update returned error: %v", err)
+	}
+
+	expected := &Contact{ID: "1", Email: "john@example.com", Status: "unsubscribed"}
+	if !reflect.DeepEqual(contact, expected) {
+		t.Errorf("Contacts.Update returned %+v, expected %+v", contact, expected)
+	}
+
+	testBadPathParams(t, "Contacts.Update", func() error {
+		_, _, err = client.Contacts.Update(1, "", updateReq)
+		return err
+	})
+}
+
+func TestContactsService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Contacts.Delete(1, "1")
+	if err != nil {
+		t.Errorf("Contacts.Delete returned error: %v", err)
+	}
+
+	mux.HandleFunc("/accounts/1/contacts/", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/accounts/1/contacts/a%2Fb@example.com"; got != want {
+			t.Errorf("Contacts.Delete request path = %s, want %s", got, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := client.Contacts.Delete(1, "a/b@example.com"); err != nil {
+		t.Errorf("Contacts.Delete with '/' in email returned error: %v", err)
+	}
+
+	testBadPathParams(t, "Contacts.Delete", func() error {
+		_, err = client.Contacts.Delete(1, "")
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Contacts.Delete", &client.client, func() (*Response, error) {
+		return client.Contacts.Delete(1, "1")
+	})
+}
+
+func contactMock(ID string) *Contact {
+	return &Contact{
+		ID:    ID,
+		Email: "john@example.com",
+		Fields: map[string]interface{}{
+			"first_name": "John",
+			"zip_code":   float64(11111),
+		},
+		ListIDs:   []int{1, 2},
+		Status:    "subscribed",
+		CreatedAt: NewTimestamp(time.UnixMilli(1700236800000).UTC()),
+		UpdatedAt: NewTimestamp(time.UnixMilli(1700236800000).UTC()),
+	}
+}
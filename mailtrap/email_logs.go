@@ -0,0 +1,233 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+type EmailLogsServiceContract interface {
+	List(accountID int, params *ListEmailLogsParams) (*EmailLogsPage, *Response, error)
+	Get(accountID int, messageID string) (*EmailLog, *Response, error)
+	Iter(accountID int, params *ListEmailLogsParams) *EmailLogsIterator
+}
+
+type EmailLogsService struct {
+	client *client
+}
+
+var _ EmailLogsServiceContract = &EmailLogsService{}
+
+// Email log statuses.
+const (
+	EmailLogDelivered    = "delivered"
+	EmailLogNotDelivered = "not_delivered"
+	EmailLogEnqueued     = "enqueued"
+	EmailLogOptedOut     = "opted_out"
+)
+
+// EmailLog represents a message sent through the sending API.
+type EmailLog struct {
+	MessageID string `json:"message_id"`
+	// status can return delivered, not_delivered, enqueued, opted_out
+	Status            string                 `json:"status"`
+	Subject           string                 `json:"subject"`
+	From              string                 `json:"from"`
+	To                string                 `json:"to"`
+	Category          string                 `json:"category"`
+	CustomVariables   map[string]string      `json:"custom_variables"`
+	SendingStream     string                 `json:"sending_stream"`
+	SendingDomainID   int                    `json:"sending_domain_id"`
+	TemplateID        int                    `json:"template_id"`
+	TemplateVariables map[string]interface{} `json:"template_variables"`
+	ClientIP          string                 `json:"client_ip"`
+	OpensCount        int                    `json:"opens_count"`
+	ClicksCount       int                    `json:"clicks_count"`
+	SentAt            Timestamp              `json:"sent_at"`
+
+	// RawMessageURL and Events are returned by Get only.
+	RawMessageURL string           `json:"raw_message_url,omitempty"`
+	Events        []*EmailLogEvent `json:"events,omitempty"`
+}
+
+// EmailLogEvent represents a delivery event of the message, e.g. delivery, open or bounce.
+// The event types are the same as the ones of webhooks.
+type EmailLogEvent struct {
+	EventType string                 `json:"event_type"`
+	CreatedAt Timestamp              `json:"created_at"`
+	Details   map[string]interface{} `json:"details"`
+}
+
+// EmailLogsPage represents a page of the email logs.
+type EmailLogsPage struct {
+	Messages   []*EmailLog `json:"messages"`
+	TotalCount int         `json:"total_count"`
+	// NextPageCursor is passed as ListEmailLogsParams.SearchAfter to get the next page.
+	// Empty for the last page.
+	NextPageCursor string `json:"next_page_cursor"`
+}
+
+// ListEmailLogsParams represents the available List() query parameters.
+type ListEmailLogsParams struct {
+	// To returns the messages of the recipient only, case insensitive.
+	To       string
+	Category string
+	Status   string
+	// SentAfter and SentBefore limit the message sending time.
+	SentAfter  time.Time
+	SentBefore time.Time
+	// SearchAfter is the cursor of the page, see EmailLogsPage.NextPageCursor.
+	SearchAfter string
+}
+
+func (p *ListEmailLogsParams) values() url.Values {
+	v := url.Values{}
+	if p == nil {
+		return v
+	}
+	if p.To != "" {
+		v.Set("filters[to][operator]", "ci_equal")
+		v.Set("filters[to][value]", p.To)
+	}
+	if p.Category != "" {
+		v.Set("filters[category][operator]", "equal")
+		v.Set("filters[category][value]", p.Category)
+	}
+	if p.Status != "" {
+		v.Set("filters[status][operator]", "equal")
+		v.Set("filters[status][value]", p.Status)
+	}
+	if !p.SentAfter.IsZero() {
+		v.Set("filters[sent_after]", p.SentAfter.UTC().Format(time.RFC3339))
+	}
+	if !p.SentBefore.IsZero() {
+		v.Set("filters[sent_before]", p.SentBefore.UTC().Format(time.RFC3339))
+	}
+	if p.SearchAfter != "" {
+		v.Set("search_after", p.SearchAfter)
+	}
+	return v
+}
+
+// List returns a page of the email logs matching the filters, newest first.
+func (s *EmailLogsService) List(accountID int, params *ListEmailLogsParams) (*EmailLogsPage, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_logs", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.values().Encode()
+
+	var page *EmailLogsPage
+	res, err := s.client.Do(req, &page)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return page, res, nil
+}
+
+// Get returns the email log by the message ID, along with its delivery events.
+func (s *EmailLogsService) Get(accountID int, messageID string) (*EmailLog, *Response, error) {
+	if messageID == "" {
+		return nil, nil, errors.New("message ID is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/email_logs/%s", accountID, url.PathEscape(messageID))
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var log *EmailLog
+	res, err := s.client.Do(req, &log)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return log, res, nil
+}
+
+// Iter returns an iterator over all email logs matching the filters.
+// The pages are requested as the iteration goes.
+func (s *EmailLogsService) Iter(accountID int, params *ListEmailLogsParams) *EmailLogsIterator {
+	it := &EmailLogsIterator{service: s, accountID: accountID}
+	if params != nil {
+		it.params = *params
+	}
+	return it
+}
+
+// Timeline returns the delivery events of the message in chronological order.
+func (l *EmailLog) Timeline() []*EmailLogEvent {
+	events := make([]*EmailLogEvent, len(l.Events))
+	copy(events, l.Events)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt.Time) })
+	return events
+}
+
+// EmailLogsIterator iterates over the email logs:
+//
+//	it := client.EmailLogs.Iter(accountID, params)
+//	for it.Next() {
+//		log := it.Log()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type EmailLogsIterator struct {
+	service   *EmailLogsService
+	accountID int
+	params    ListEmailLogsParams
+
+	page    []*EmailLog
+	current *EmailLog
+	started bool
+	err     error
+}
+
+// Next advances the iterator to the next email log. It returns false when
+// there are no more logs or an error occurred.
+func (it *EmailLogsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || (it.started && it.params.SearchAfter == "") {
+			it.current = nil
+			return false
+		}
+		it.started = true
+
+		page, _, err := it.service.List(it.accountID, &it.params)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+		if page == nil {
+			page = &EmailLogsPage{}
+		}
+		it.page = page.Messages
+		cursor := page.NextPageCursor
+		if cursor == it.params.SearchAfter {
+			// Guard against a cursor which doesn't advance, the next page would be the same.
+			cursor = ""
+		}
+		it.params.SearchAfter = cursor
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Log returns the current email log.
+func (it *EmailLogsIterator) Log() *EmailLog {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *EmailLogsIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,274 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmailLogsService_Marshal(t *testing.T) {
+	want := `{
+		"message_id": "a1b2c3d4-0000-4000-8000-000000000001",
+		"status": "delivered",
+		"subject": "Your order",
+		"from": "shop@example.com",
+		"to": "john@example.com",
+		"category": "Orders",
+		"custom_variables": {"order_id": "123"},
+		"sending_stream": "transactional",
+		"sending_domain_id": 3,
+		"template_id": 0,
+		"template_variables": null,
+		"client_ip": "203.0.113.10",
+		"opens_count": 1,
+		"clicks_count": 0,
+		"sent_at": "2024-03-01T10:00:00Z"
+	}`
+	testJSONMarshal(t, emailLogMock("a1b2c3d4-0000-4000-8000-000000000001"), want)
+}
+
+func TestEmailLogsService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedPage := &EmailLogsPage{
+		Messages:       []*EmailLog{emailLogMock("1"), emailLogMock("2")},
+		TotalCount:     3,
+		NextPageCursor: "cursor-2",
+	}
+
+	mux.HandleFunc("/accounts/1/email_logs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := url.Values{
+			"filters[to][operator]":       {"ci_equal"},
+			"filters[to][value]":          {"john@example.com"},
+			"filters[category][operator]": {"equal"},
+			"filters[category][value]":    {"Orders"},
+			"filters[status][operator]":   {"equal"},
+			"filters[status][value]":      {"delivered"},
+			"filters[sent_after]":         {"2024-03-01T00:00:00Z"},
+			"filters[sent_before]":        {"2024-03-02T00:00:00Z"},
+			"search_after":                {"cursor-1"},
+		}
+		if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Request query is %v, want %v", got, want)
+		}
+		resp, _ := json.Marshal(expectedPage)
+		fmt.Fprint(w, string(resp))
+	})
+
+	params := &ListEmailLogsParams{
+		To:          "john@example.com",
+		Category:    "Orders",
+		Status:      EmailLogDelivered,
+		SentAfter:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		SentBefore:  time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		SearchAfter: "cursor-1",
+	}
+	page, _, err := client.EmailLogs.List(1, params)
+	if err != nil {
+		t.Errorf("EmailLogs.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(page, expectedPage) {
+		t.Errorf("EmailLogs.List returned %+v, expected %+v", page, expectedPage)
+	}
+
+	testBadPathParams(t, "EmailLogs.List", func() error {
+		_, _, err = client.EmailLogs.List(-1, nil)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "EmailLogs.List", &client.client, func() (*Response, error) {
+		page, resp, err := client.EmailLogs.List(1, params)
+		if page != nil {
+			t.Errorf("EmailLogs.List client.BaseURL.Host=%v page=%#v, want nil", client.baseURL.Host, page)
+		}
+		return resp, err
+	})
+}
+
+func TestEmailLogsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_logs/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"message_id": "1",
+			"status": "delivered",
+			"raw_message_url": "https://storage.example.com/1.eml",
+			"events": [
+				{"event_type": "open", "created_at": "2024-03-01T10:05:00Z", "details": {"ip": "198.51.100.1"}},
+				{"event_type": "delivery", "created_at": "2024-03-01T10:00:01Z", "details": {}}
+			]
+		}`)
+	})
+
+	log, _, err := client.EmailLogs.Get(1, "1")
+	if err != nil {
+		t.Fatalf("EmailLogs.Get returned error: %v", err)
+	}
+
+	mux.HandleFunc("/accounts/1/email_logs/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); got != "/accounts/1/email_logs/id%2Fwebhooks%2F5" {
+			t.Errorf("EmailLogs.Get request path = %s, want the ID escaped", got)
+		}
+		fmt.Fprint(w, `{"message_id": "id/webhooks/5"}`)
+	})
+	if escaped, _, err := client.EmailLogs.Get(1, "id/webhooks/5"); err != nil || escaped.MessageID != "id/webhooks/5" {
+		t.Errorf("EmailLogs.Get with '/' in ID returned %+v, %v", escaped, err)
+	}
+
+	if log.MessageID != "1" || log.RawMessageURL != "https://storage.example.com/1.eml" || len(log.Events) != 2 {
+		t.Errorf("EmailLogs.Get returned %+v", log)
+	}
+
+	var types []string
+	for _, e := range log.Timeline() {
+		types = append(types, e.EventType)
+	}
+	if want := []string{EventDelivery, EventOpen}; !reflect.DeepEqual(types, want) {
+		t.Errorf("EmailLog.Timeline returned %v, want %v", types, want)
+	}
+	if log.Events[0].EventType != EventOpen {
+		t.Error("EmailLog.Timeline reordered the log events")
+	}
+
+	testBadPathParams(t, "EmailLogs.Get", func() error {
+		_, _, err = client.EmailLogs.Get(1, "")
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "EmailLogs.Get", &client.client, func() (*Response, error) {
+		log, resp, err := client.EmailLogs.Get(1, "1")
+		if log != nil {
+			t.Errorf("EmailLogs.Get client.BaseURL.Host=%v log=%#v, want nil", client.baseURL.Host, log)
+		}
+		return resp, err
+	})
+}
+
+func TestEmailLogsService_Iter(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	pages := map[string]*EmailLogsPage{
+		"":   {Messages: []*EmailLog{emailLogMock("1"), emailLogMock("2")}, NextPageCursor: "c1"},
+		"c1": {Messages: []*EmailLog{}, NextPageCursor: "c2"},
+		"c2": {Messages: []*EmailLog{emailLogMock("3")}},
+	}
+	mux.HandleFunc("/accounts/1/email_logs", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filters[status][value]"); got != EmailLogNotDelivered {
+			t.Errorf("Request status filter is %q, want %q", got, EmailLogNotDelivered)
+		}
+		page, ok := pages[r.URL.Query().Get("search_after")]
+		if !ok {
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("search_after"))
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	params := &ListEmailLogsParams{Status: EmailLogNotDelivered}
+	it := client.EmailLogs.Iter(1, params)
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Log().MessageID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("EmailLogsIterator.Err returned %v", err)
+	}
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("EmailLogsIterator returned %v, want %v", ids, want)
+	}
+	if it.Next() || it.Log() != nil {
+		t.Error("EmailLogsIterator.Next after the end returned true")
+	}
+	if params.SearchAfter != "" {
+		t.Error("EmailLogs.Iter modified the params")
+	}
+}
+
+func TestEmailLogsService_IterStuckCursor(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/accounts/1/email_logs", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 3 {
+			t.Fatalf("EmailLogsIterator made %d requests, want it to stop", requests)
+		}
+		switch r.URL.Query().Get("search_after") {
+		case "":
+			json.NewEncoder(w).Encode(&EmailLogsPage{Messages: []*EmailLog{emailLogMock("1")}, NextPageCursor: "c1"})
+		default:
+			// The server keeps returning the same non-empty page and cursor.
+			json.NewEncoder(w).Encode(&EmailLogsPage{Messages: []*EmailLog{emailLogMock("2")}, NextPageCursor: "c1"})
+		}
+	})
+
+	var ids []string
+	it := client.EmailLogs.Iter(1, nil)
+	for it.Next() {
+		ids = append(ids, it.Log().MessageID)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(ids, want) || it.Err() != nil {
+		t.Errorf("EmailLogsIterator returned %v, %v, want %v", ids, it.Err(), want)
+	}
+}
+
+func TestEmailLogsService_IterNullPage(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `null`)
+	})
+
+	it := client.EmailLogs.Iter(1, nil)
+	if it.Next() || it.Err() != nil {
+		t.Errorf("EmailLogsIterator over null page returned %v, %v, want no logs", it.Log(), it.Err())
+	}
+}
+
+func TestEmailLogsService_IterFail(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_logs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"Incorrect API token"}`)
+	})
+
+	it := client.EmailLogs.Iter(1, nil)
+	if it.Next() {
+		t.Error("EmailLogsIterator.Next returned true, want false")
+	}
+	if it.Err() == nil {
+		t.Error("EmailLogsIterator.Err returned nil, want error")
+	}
+}
+
+func emailLogMock(messageID string) *EmailLog {
+	return &EmailLog{
+		MessageID:       messageID,
+		Status:          EmailLogDelivered,
+		Subject:         "Your order",
+		From:            "shop@example.com",
+		To:              "john@example.com",
+		Category:        "Orders",
+		CustomVariables: map[string]string{"order_id": "123"},
+		SendingStream:   "transactional",
+		SendingDomainID: 3,
+		ClientIP:        "203.0.113.10",
+		OpensCount:      1,
+		SentAt:          NewTimestamp(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)),
+	}
+}
@@ -0,0 +1,130 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type EmailTemplatesServiceContract interface {
+	List(accountID int) ([]*EmailTemplate, *Response, error)
+	Get(accountID, templateID int) (*EmailTemplate, *Response, error)
+	Create(accountID int, templateReq *EmailTemplateRequest) (*EmailTemplate, *Response, error)
+	Update(accountID, templateID int, templateReq *EmailTemplateRequest) (*EmailTemplate, *Response, error)
+	Delete(accountID, templateID int) (*Response, error)
+}
+
+type EmailTemplatesService struct {
+	client *client
+}
+
+var _ EmailTemplatesServiceContract = &EmailTemplatesService{}
+
+// EmailTemplate represents a Mailtrap email template.
+type EmailTemplate struct {
+	ID int `json:"id"`
+	// UUID identifies the template when sending, see SendEmailRequest.TemplateUUID.
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	Category  string    `json:"category"`
+	BodyHTML  string    `json:"body_html"`
+	BodyText  string    `json:"body_text"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// EmailTemplateRequest represents the request to create or update email template.
+// On update only the set fields are changed.
+type EmailTemplateRequest struct {
+	Name     string `json:"name,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	Category string `json:"category,omitempty"`
+	BodyHTML string `json:"body_html,omitempty"`
+	BodyText string `json:"body_text,omitempty"`
+}
+
+type emailTemplateRequest struct {
+	EmailTemplate *EmailTemplateRequest `json:"email_template"`
+}
+
+// List returns the email templates of the account.
+func (s *EmailTemplatesService) List(accountID int) ([]*EmailTemplate, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var templates []*EmailTemplate
+	res, err := s.client.Do(req, &templates)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return templates, res, nil
+}
+
+// Get returns the email template by ID.
+func (s *EmailTemplatesService) Get(accountID, templateID int) (*EmailTemplate, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates an email template.
+func (s *EmailTemplatesService) Create(
+	accountID int,
+	templateReq *EmailTemplateRequest,
+) (*EmailTemplate, *Response, error) {
+	if templateReq == nil || templateReq.Name == "" || templateReq.Subject == "" || templateReq.Category == "" {
+		return nil, nil, errors.New("email template 'name', 'subject' and 'category' are required")
+	}
+	if templateReq.BodyHTML == "" && templateReq.BodyText == "" {
+		return nil, nil, errors.New("one of email template 'body_html' or 'body_text' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/email_templates", accountID)
+	return s.makeRequest(u, http.MethodPost, &emailTemplateRequest{EmailTemplate: templateReq})
+}
+
+// Update updates the email template.
+func (s *EmailTemplatesService) Update(
+	accountID, templateID int,
+	templateReq *EmailTemplateRequest,
+) (*EmailTemplate, *Response, error) {
+	if templateReq == nil {
+		return nil, nil, errors.New("request `EmailTemplateRequest` is mandatory")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	return s.makeRequest(u, http.MethodPatch, &emailTemplateRequest{EmailTemplate: templateReq})
+}
+
+// Delete removes the email template.
+func (s *EmailTemplatesService) Delete(accountID, templateID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *EmailTemplatesService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*EmailTemplate, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var template *EmailTemplate
+	res, err := s.client.Do(req, &template)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return template, res, nil
+}
@@ -0,0 +1,186 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmailTemplatesService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &EmailTemplate{}, `{"created_at":null,"updated_at":null}`)
+
+	want := `{
+		"id": 1,
+		"uuid": "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d",
+		"name": "Welcome",
+		"subject": "Welcome, {{user_name}}",
+		"category": "Onboarding",
+		"body_html": "<p>Hello, {{user_name}}</p>",
+		"body_text": "Hello, {{user_name}}",
+		"created_at": "2024-01-01T10:00:00Z",
+		"updated_at": "2024-01-02T10:00:00Z"
+	}`
+	testJSONMarshal(t, emailTemplateMock(1), want)
+}
+
+func TestEmailTemplatesService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedTemplates := []*EmailTemplate{emailTemplateMock(1), emailTemplateMock(2)}
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedTemplates)
+		fmt.Fprint(w, string(resp))
+	})
+
+	templates, _, err := client.EmailTemplates.List(1)
+	if err != nil {
+		t.Errorf("EmailTemplates.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(templates, expectedTemplates) {
+		t.Errorf("EmailTemplates.List returned %+v, expected %+v", templates, expectedTemplates)
+	}
+
+	testBadPathParams(t, "EmailTemplates.List", func() error {
+		_, _, err = client.EmailTemplates.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "EmailTemplates.List", &client.client, func() (*Response, error) {
+		templates, resp, err := client.EmailTemplates.List(1)
+		if templates != nil {
+			t.Errorf("EmailTemplates.List client.BaseURL.Host=%v templates=%#v, want nil", client.baseURL.Host, templates)
+		}
+		return resp, err
+	})
+}
+
+func TestEmailTemplatesService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(emailTemplateMock(2))
+		fmt.Fprint(w, string(resp))
+	})
+
+	template, _, err := client.EmailTemplates.Get(1, 2)
+	if err != nil {
+		t.Errorf("EmailTemplates.Get returned error: %v", err)
+	}
+
+	if expected := emailTemplateMock(2); !reflect.DeepEqual(template, expected) {
+		t.Errorf("EmailTemplates.Get returned %+v, expected %+v", template, expected)
+	}
+
+	testNewRequestAndDoFail(t, "EmailTemplates.Get", &client.client, func() (*Response, error) {
+		template, resp, err := client.EmailTemplates.Get(1, 2)
+		if template != nil {
+			t.Errorf("EmailTemplates.Get client.BaseURL.Host=%v template=%#v, want nil", client.baseURL.Host, template)
+		}
+		return resp, err
+	})
+}
+
+func TestEmailTemplatesService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"email_template":{"name":"Welcome","subject":"Welcome, {{user_name}}","category":"Onboarding","body_html":"\u003cp\u003eHello, {{user_name}}\u003c/p\u003e"}}`)
+		resp, _ := json.Marshal(emailTemplateMock(1))
+		fmt.Fprint(w, string(resp))
+	})
+
+	createReq := &EmailTemplateRequest{
+		Name:     "Welcome",
+		Subject:  "Welcome, {{user_name}}",
+		Category: "Onboarding",
+		BodyHTML: "<p>Hello, {{user_name}}</p>",
+	}
+	template, _, err := client.EmailTemplates.Create(1, createReq)
+	if err != nil {
+		t.Errorf("EmailTemplates.Create returned error: %v", err)
+	}
+
+	if expected := emailTemplateMock(1); !reflect.DeepEqual(template, expected) {
+		t.Errorf("EmailTemplates.Create returned %+v, expected %+v", template, expected)
+	}
+
+	_, _, err = client.EmailTemplates.Create(1, &EmailTemplateRequest{Name: "Welcome"})
+	if err == nil {
+		t.Error("EmailTemplates.Create without subject, err = nil, want error")
+	}
+
+	_, _, err = client.EmailTemplates.Create(1, &EmailTemplateRequest{Name: "Welcome", Subject: "Hi", Category: "Onboarding"})
+	if err == nil {
+		t.Error("EmailTemplates.Create without body, err = nil, want error")
+	}
+}
+
+func TestEmailTemplatesService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"email_template":{"subject":"Hi there"}}`)
+		fmt.Fprint(w, `{"id":1,"uuid":"6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d","name":"Welcome","subject":"Hi there"}`)
+	})
+
+	template, _, err := client.EmailTemplates.Update(1, 1, &EmailTemplateRequest{Subject: "Hi there"})
+	if err != nil {
+		t.Errorf("EmailTemplates.Update returned error: %v", err)
+	}
+
+	expected := &EmailTemplate{ID: 1, UUID: "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", Name: "Welcome", Subject: "Hi there"}
+	if !reflect.DeepEqual(template, expected) {
+		t.Errorf("EmailTemplates.Update returned %+v, expected %+v", template, expected)
+	}
+
+	_, _, err = client.EmailTemplates.Update(1, 1, nil)
+	if err == nil {
+		t.Error("EmailTemplates.Update without request, err = nil, want error")
+	}
+}
+
+func TestEmailTemplatesService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.EmailTemplates.Delete(1, 1)
+	if err != nil {
+		t.Errorf("EmailTemplates.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "EmailTemplates.Delete", &client.client, func() (*Response, error) {
+		return client.EmailTemplates.Delete(1, 1)
+	})
+}
+
+func emailTemplateMock(ID int) *EmailTemplate {
+	return &EmailTemplate{
+		ID:        ID,
+		UUID:      "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d",
+		Name:      "Welcome",
+		Subject:   "Welcome, {{user_name}}",
+		Category:  "Onboarding",
+		BodyHTML:  "<p>Hello, {{user_name}}</p>",
+		BodyText:  "Hello, {{user_name}}",
+		CreatedAt: NewTimestamp(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+		UpdatedAt: NewTimestamp(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)),
+	}
+}
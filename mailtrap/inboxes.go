@@ -44,7 +44,7 @@ type Inbox struct {
 	EmailDomain             string      `json:"email_domain"`
 	EmailsCount             int         `json:"emails_count"`
 	EmailsUnreadCount       int         `json:"emails_unread_count"`
-	LastMessageSentAt       string      `json:"last_message_sent_at"`
+	LastMessageSentAt       Timestamp   `json:"last_message_sent_at"`
 	SMTPPorts               []int       `json:"smtp_ports"`
 	POP3Ports               []int       `json:"pop3_ports"`
 	MaxMessageSize          int         `json:"max_message_size"`
@@ -306,7 +306,7 @@ func inboxMock(ID int) *Inbox {
 		EmailDomain:             "localhost",
 		EmailsCount:             10,
 		EmailsUnreadCount:       0,
-		LastMessageSentAt:       "",
+		LastMessageSentAt:       Timestamp{},
 		SMTPPorts:               []int{25, 2525},
 		POP3Ports:               []int{1100},
 		MaxMessageSize:          2000,
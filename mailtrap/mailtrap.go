@@ -43,6 +43,12 @@ type client struct {
 // SendingClient manages communication with the Mailtrap sending API.
 type SendingClient struct {
 	client
+
+	// Filters applied to each request before it is sent.
+	filters []SendFilter
+
+	// Hooks called after each successful send.
+	hooks []SendHook
 }
 
 // TestingClient manages communication with the Mailtrap testing API.
@@ -53,10 +59,24 @@ type TestingClient struct {
 	Accounts     *AccountsService
 	AccountUsers *AccountUsersService
 	Permissions  *PermissionsService
+	APITokens    *APITokensService
 	Projects     *ProjectsService
 	Inboxes      *InboxesService
 	Messages     *MessagesService
 	Attachments  *AttachmentsService
+
+	// Services used for managing the account email sending.
+	Webhooks       *WebhooksService
+	SendingDomains *SendingDomainsService
+	Suppressions   *SuppressionsService
+	Contacts       *ContactsService
+	ContactLists   *ContactListsService
+	ContactImports *ContactImportsService
+	ContactExports *ContactExportsService
+	ContactFields  *ContactFieldsService
+	EmailTemplates *EmailTemplatesService
+	EmailLogs      *EmailLogsService
+	Stats          *StatsService
 }
 
 // NewSendingClient creates and returns an instance of SendingClient.
@@ -68,7 +88,7 @@ func NewSendingClient(apiKey string) (*SendingClient, error) {
 	baseURL.Path += apiSuffix
 
 	client := &SendingClient{
-		client{
+		client: client{
 			apiKey:     apiKey,
 			baseURL:    baseURL,
 			httpClient: http.DefaultClient,
@@ -100,10 +120,22 @@ func NewTestingClient(apiKey string) (*TestingClient, error) {
 	client.Accounts = &AccountsService{client: &client.client}
 	client.AccountUsers = &AccountUsersService{client: &client.client}
 	client.Permissions = &PermissionsService{client: &client.client}
+	client.APITokens = &APITokensService{client: &client.client}
 	client.Projects = &ProjectsService{client: &client.client}
 	client.Inboxes = &InboxesService{client: &client.client}
 	client.Messages = &MessagesService{client: &client.client}
 	client.Attachments = &AttachmentsService{client: &client.client}
+	client.Webhooks = &WebhooksService{client: &client.client}
+	client.SendingDomains = &SendingDomainsService{client: &client.client}
+	client.Suppressions = &SuppressionsService{client: &client.client}
+	client.Contacts = &ContactsService{client: &client.client}
+	client.ContactLists = &ContactListsService{client: &client.client}
+	client.ContactImports = &ContactImportsService{client: &client.client}
+	client.ContactExports = &ContactExportsService{client: &client.client}
+	client.ContactFields = &ContactFieldsService{client: &client.client}
+	client.EmailTemplates = &EmailTemplatesService{client: &client.client}
+	client.EmailLogs = &EmailLogsService{client: &client.client}
+	client.Stats = &StatsService{client: &client.client}
 
 	return client, nil
 }
@@ -134,6 +166,23 @@ func (c *client) Do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 
+// doStream sends the request and returns the response body unread.
+// The caller must close the body.
+func (c *client) doStream(req *http.Request) (io.ReadCloser, *Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &Response{Response: resp}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, response, err
+	}
+
+	return resp.Body, response, nil
+}
+
 func (c *client) decode(v interface{}, body io.Reader, acceptHeader string) error {
 	if body == nil {
 		return nil
@@ -157,15 +206,18 @@ func (c *client) decode(v interface{}, body io.Reader, acceptHeader string) erro
 }
 
 // NewRequest creates an API request.
+// The path is relative to the base URL and must be escaped,
+// i.e. the caller supplied segments must be escaped with url.PathEscape.
 func (c *client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
-	u := c.baseURL
-	u.Path = c.baseURL.Path + path
-
-	var (
-		req *http.Request
-		err error
-	)
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, err
+	}
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + unescaped
+	u.RawPath = c.baseURL.EscapedPath() + path
 
+	var req *http.Request
 	switch method {
 	case http.MethodGet, http.MethodHead, http.MethodOptions:
 		req, err = http.NewRequest(method, u.String(), nil)
@@ -48,6 +48,17 @@ func testHeader(t *testing.T, r *http.Request, header string, want string) {
 	}
 }
 
+func testBody(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Error reading request body: %v", err)
+	}
+	if got := strings.TrimSpace(string(b)); got != want {
+		t.Errorf("Request body is %s, want %s", got, want)
+	}
+}
+
 func testBadPathParams(t *testing.T, method string, fn func() error) {
 	t.Helper()
 	if err := fn(); err == nil {
@@ -159,6 +170,36 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestNewRequest_escapedPath(t *testing.T) {
+	c, _ := NewTestingClient("")
+
+	req, err := c.NewRequest(http.MethodGet, "/accounts/1/contacts/"+url.PathEscape("a/b c@example.com"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if want := testingAPIURL + "api/accounts/1/contacts/a%2Fb%20c@example.com"; req.URL.String() != want {
+		t.Errorf("NewRequest() URL = %v, expected %v", req.URL, want)
+	}
+
+	if _, err := c.NewRequest(http.MethodGet, "/accounts/%zz", nil); err == nil {
+		t.Error("NewRequest() with invalid escaping, err = nil, want error")
+	}
+}
+
+func TestNewRequest_baseURLUnchanged(t *testing.T) {
+	c, _ := NewTestingClient("")
+
+	_, _ = c.NewRequest(http.MethodGet, "/accounts", nil)
+	req, _ := c.NewRequest(http.MethodGet, "/accounts/1/inboxes", nil)
+
+	if want := testingAPIURL + "api/accounts/1/inboxes"; req.URL.String() != want {
+		t.Errorf("NewRequest() URL = %v, expected %v", req.URL, want)
+	}
+	if want := testingAPIURL + apiSuffix; c.baseURL.String() != want {
+		t.Errorf("NewRequest() changed client baseURL to %v, expected %v", c.baseURL, want)
+	}
+}
+
 func TestDo(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -0,0 +1,215 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"sync"
+)
+
+// defaultBulkConcurrency is the number of messages processed in parallel by default.
+const defaultBulkConcurrency = 4
+
+// MessageSelector selects the messages of the account inboxes for the bulk operations.
+// All pages of the inbox messages are listed, not only the first one.
+type MessageSelector struct {
+	AccountID int
+	// InboxIDs are the inboxes to select the messages from. All inboxes of the account are used if empty.
+	InboxIDs []int
+	// Filter reports whether the message is selected. All messages are selected if nil.
+	Filter func(*Message) bool
+}
+
+// BulkOptions represents the options of the bulk message operations.
+type BulkOptions struct {
+	// Concurrency is the number of messages processed in parallel. Defaults to 4.
+	Concurrency int
+	// Progress is called after each message with the number of processed and total messages. Optional.
+	// The calls are serialized.
+	Progress func(processed, total int)
+}
+
+// BulkItemError represents a message which has not been processed.
+type BulkItemError struct {
+	InboxID   int
+	MessageID int
+	Err       error
+}
+
+func (e *BulkItemError) Error() string {
+	return fmt.Sprintf("inbox %d message %d: %v", e.InboxID, e.MessageID, e.Err)
+}
+
+func (e *BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkResult represents the summary of the bulk operation.
+type BulkResult struct {
+	// Selected is the number of the selected messages.
+	Selected int
+	// Processed contains the messages processed successfully, in order of selection.
+	// The updated messages are returned by BulkUpdate.
+	Processed []*Message
+	// Errors contains the messages which failed, in order of selection.
+	Errors []*BulkItemError
+}
+
+// Err returns the first item error, or nil if all messages have been processed.
+func (r *BulkResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	if len(r.Errors) == 1 {
+		return r.Errors[0]
+	}
+	return fmt.Errorf("%d of %d messages failed, first: %w", len(r.Errors), r.Selected, r.Errors[0])
+}
+
+// BulkDelete deletes the selected messages.
+// The returned error is set if the messages could not be selected, the failures
+// of the individual messages are reported in the result.
+func (s *MessagesService) BulkDelete(sel *MessageSelector, opts *BulkOptions) (*BulkResult, error) {
+	return s.bulk(sel, opts, func(m *Message) (*Message, error) {
+		_, err := s.Delete(sel.AccountID, m.InboxID, m.ID)
+		return m, err
+	})
+}
+
+// BulkUpdate updates attributes of the selected messages.
+// The returned error is set if the messages could not be selected, the failures
+// of the individual messages are reported in the result.
+func (s *MessagesService) BulkUpdate(
+	sel *MessageSelector,
+	updateReq *UpdateMessageRequest,
+	opts *BulkOptions,
+) (*BulkResult, error) {
+	if updateReq == nil {
+		return nil, errors.New("update request is nil")
+	}
+
+	return s.bulk(sel, opts, func(m *Message) (*Message, error) {
+		updated, _, err := s.Update(sel.AccountID, m.InboxID, m.ID, updateReq)
+		return updated, err
+	})
+}
+
+// BulkForward forwards the selected messages to the email address.
+// The returned error is set if the messages could not be selected, the failures
+// of the individual messages are reported in the result.
+func (s *MessagesService) BulkForward(sel *MessageSelector, email string, opts *BulkOptions) (*BulkResult, error) {
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, errors.New("forward 'email' is invalid")
+	}
+
+	return s.bulk(sel, opts, func(m *Message) (*Message, error) {
+		_, err := s.Forward(sel.AccountID, m.InboxID, m.ID, email)
+		return m, err
+	})
+}
+
+// bulk runs the operation on the selected messages with a bounded worker pool.
+func (s *MessagesService) bulk(
+	sel *MessageSelector,
+	opts *BulkOptions,
+	op func(*Message) (*Message, error),
+) (*BulkResult, error) {
+	messages, err := s.selectMessages(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &BulkOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	var (
+		processed = make([]*Message, len(messages))
+		errs      = make([]error, len(messages))
+		jobs      = make(chan int)
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		done      int
+	)
+	for w := 0; w < concurrency && w < len(messages); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				processed[i], errs[i] = op(messages[i])
+
+				if opts.Progress != nil {
+					mu.Lock()
+					done++
+					opts.Progress(done, len(messages))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range messages {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := &BulkResult{Selected: len(messages)}
+	for i, m := range messages {
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, &BulkItemError{InboxID: m.InboxID, MessageID: m.ID, Err: errs[i]})
+			continue
+		}
+		result.Processed = append(result.Processed, processed[i])
+	}
+
+	return result, nil
+}
+
+// selectMessages lists the messages of the selected inboxes matching the filter.
+func (s *MessagesService) selectMessages(sel *MessageSelector) ([]*Message, error) {
+	if sel == nil {
+		return nil, errors.New("message selector is nil")
+	}
+
+	inboxIDs := sel.InboxIDs
+	if len(inboxIDs) == 0 {
+		inboxes, _, err := (&InboxesService{client: s.client}).List(sel.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("list inboxes: %w", err)
+		}
+		for _, inbox := range inboxes {
+			inboxIDs = append(inboxIDs, inbox.ID)
+		}
+	}
+
+	var selected []*Message
+	for _, inboxID := range inboxIDs {
+		// The messages are listed page by page until an empty page,
+		// or a page ending with the same message as the previous one.
+		lastID := 0
+		for {
+			messages, _, err := s.listPage(sel.AccountID, inboxID, lastID)
+			if err != nil {
+				return nil, fmt.Errorf("list messages of inbox %d: %w", inboxID, err)
+			}
+			if len(messages) == 0 || messages[len(messages)-1].ID == lastID {
+				break
+			}
+			lastID = messages[len(messages)-1].ID
+
+			for _, m := range messages {
+				// The inbox ID is used to address the message, so it must be set.
+				m.InboxID = inboxID
+				if sel.Filter == nil || sel.Filter(m) {
+					selected = append(selected, m)
+				}
+			}
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,239 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bulkServer serves the messages of inboxes 2 and 5 and records the listed pages
+// and the processed messages.
+type bulkServer struct {
+	mu        sync.Mutex
+	pages     []string
+	requests  []string
+	active    int
+	maxActive int
+}
+
+func (b *bulkServer) register(t *testing.T, mux *http.ServeMux) {
+	inboxMessages := map[int][]int{2: {1, 2, 3}, 5: {4}}
+
+	mux.HandleFunc("/accounts/1/inboxes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal([]*Inbox{inboxMock(2), inboxMock(5)})
+		fmt.Fprint(w, string(resp))
+	})
+
+	for inboxID, ids := range inboxMessages {
+		inboxID, ids := inboxID, ids
+		mux.HandleFunc(fmt.Sprintf("/accounts/1/inboxes/%d/messages", inboxID), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			b.mu.Lock()
+			b.pages = append(b.pages, r.URL.String())
+			b.mu.Unlock()
+
+			// The messages are served in pages of two following the last_id message.
+			page := ids
+			if lastID := r.URL.Query().Get("last_id"); lastID != "" {
+				for i, id := range ids {
+					if strconv.Itoa(id) == lastID {
+						page = ids[i+1:]
+					}
+				}
+			}
+			if len(page) > 2 {
+				page = page[:2]
+			}
+
+			var messages []*Message
+			for _, id := range page {
+				m := messageMock(id)
+				m.InboxID = 0
+				m.Subject = fmt.Sprintf("Message %d", id)
+				messages = append(messages, m)
+			}
+			resp, _ := json.Marshal(messages)
+			fmt.Fprint(w, string(resp))
+		})
+
+		mux.HandleFunc(fmt.Sprintf("/accounts/1/inboxes/%d/messages/", inboxID), func(w http.ResponseWriter, r *http.Request) {
+			b.mu.Lock()
+			b.active++
+			if b.active > b.maxActive {
+				b.maxActive = b.active
+			}
+			b.requests = append(b.requests, r.Method+" "+r.URL.Path)
+			b.mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			b.mu.Lock()
+			b.active--
+			b.mu.Unlock()
+
+			if strings.HasSuffix(r.URL.Path, "/messages/3") || strings.HasSuffix(r.URL.Path, "/messages/3/forward") {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":"Not Found"}`)
+				return
+			}
+			var id int
+			fmt.Sscanf(r.URL.Path[strings.LastIndex(r.URL.Path, "/messages/")+len("/messages/"):], "%d", &id)
+			m := messageMock(id)
+			m.InboxID = inboxID
+			m.IsRead = r.Method == http.MethodPatch
+			resp, _ := json.Marshal(m)
+			fmt.Fprint(w, string(resp))
+		})
+	}
+}
+
+func (b *bulkServer) sortedRequests() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	requests := append([]string(nil), b.requests...)
+	sort.Strings(requests)
+	return requests
+}
+
+func TestMessagesService_BulkDelete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	server := &bulkServer{}
+	server.register(t, mux)
+
+	var progress []string
+	sel := &MessageSelector{
+		AccountID: 1,
+		Filter:    func(m *Message) bool { return m.Subject != "Message 2" },
+	}
+	opts := &BulkOptions{
+		Concurrency: 2,
+		Progress: func(processed, total int) {
+			progress = append(progress, fmt.Sprintf("%d/%d", processed, total))
+		},
+	}
+
+	result, err := client.Messages.BulkDelete(sel, opts)
+	if err != nil {
+		t.Fatalf("Messages.BulkDelete returned error: %v", err)
+	}
+
+	want := []string{
+		"DELETE /accounts/1/inboxes/2/messages/1",
+		"DELETE /accounts/1/inboxes/2/messages/3",
+		"DELETE /accounts/1/inboxes/5/messages/4",
+	}
+	if got := server.sortedRequests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages.BulkDelete requests = %v, want %v", got, want)
+	}
+	wantPages := []string{
+		"/accounts/1/inboxes/2/messages",
+		"/accounts/1/inboxes/2/messages?last_id=2",
+		"/accounts/1/inboxes/2/messages?last_id=3",
+		"/accounts/1/inboxes/5/messages",
+		"/accounts/1/inboxes/5/messages?last_id=4",
+	}
+	if !reflect.DeepEqual(server.pages, wantPages) {
+		t.Errorf("Messages.BulkDelete listed pages %v, want %v", server.pages, wantPages)
+	}
+	if server.maxActive > 2 {
+		t.Errorf("Messages.BulkDelete ran %d requests in parallel, want at most 2", server.maxActive)
+	}
+	if !reflect.DeepEqual(progress, []string{"1/3", "2/3", "3/3"}) {
+		t.Errorf("Messages.BulkDelete progress = %v", progress)
+	}
+
+	if result.Selected != 3 || len(result.Processed) != 2 || result.Processed[0].ID != 1 || result.Processed[1].ID != 4 {
+		t.Errorf("Messages.BulkDelete returned %+v", result)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].InboxID != 2 || result.Errors[0].MessageID != 3 {
+		t.Fatalf("Messages.BulkDelete errors = %v", result.Errors)
+	}
+	var errResp *ErrorResponse
+	if !errors.As(result.Err(), &errResp) || errResp.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("BulkResult.Err = %v, want not found error", result.Err())
+	}
+}
+
+func TestMessagesService_BulkUpdate(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	server := &bulkServer{}
+	server.register(t, mux)
+
+	sel := &MessageSelector{AccountID: 1, InboxIDs: []int{5}}
+	result, err := client.Messages.BulkUpdate(sel, &UpdateMessageRequest{IsRead: true}, nil)
+	if err != nil {
+		t.Fatalf("Messages.BulkUpdate returned error: %v", err)
+	}
+	if len(result.Processed) != 1 || !result.Processed[0].IsRead || result.Err() != nil {
+		t.Errorf("Messages.BulkUpdate returned %+v", result)
+	}
+	if got := server.sortedRequests(); !reflect.DeepEqual(got, []string{"PATCH /accounts/1/inboxes/5/messages/4"}) {
+		t.Errorf("Messages.BulkUpdate requests = %v", got)
+	}
+
+	if _, err := client.Messages.BulkUpdate(sel, nil, nil); err == nil {
+		t.Error("Messages.BulkUpdate with nil request, err = nil, want error")
+	}
+}
+
+func TestMessagesService_BulkForward(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	server := &bulkServer{}
+	server.register(t, mux)
+
+	sel := &MessageSelector{AccountID: 1, InboxIDs: []int{2}}
+	result, err := client.Messages.BulkForward(sel, "qa@example.com", &BulkOptions{Concurrency: 10})
+	if err != nil {
+		t.Fatalf("Messages.BulkForward returned error: %v", err)
+	}
+	if result.Selected != 3 || len(result.Processed) != 2 || len(result.Errors) != 1 {
+		t.Errorf("Messages.BulkForward returned %+v", result)
+	}
+	if err := result.Err(); err == nil || !strings.Contains(err.Error(), "inbox 2 message 3") {
+		t.Errorf("BulkResult.Err = %v", err)
+	}
+
+	if _, err := client.Messages.BulkForward(sel, "invalid", nil); err == nil {
+		t.Error("Messages.BulkForward with invalid email, err = nil, want error")
+	}
+}
+
+func TestMessagesService_Bulk_selectionFailed(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	server := &bulkServer{}
+	server.register(t, mux)
+
+	if _, err := client.Messages.BulkDelete(nil, nil); err == nil {
+		t.Error("Messages.BulkDelete with nil selector, err = nil, want error")
+	}
+
+	result, err := client.Messages.BulkDelete(&MessageSelector{AccountID: 1, InboxIDs: []int{2, 9}}, nil)
+	if err == nil || result != nil {
+		t.Errorf("Messages.BulkDelete with missing inbox returned %+v, %v, want error", result, err)
+	}
+	if requests := server.sortedRequests(); len(requests) != 0 {
+		t.Errorf("Messages.BulkDelete with failed selection processed %v", requests)
+	}
+
+	result, err = client.Messages.BulkDelete(&MessageSelector{AccountID: 1, Filter: func(*Message) bool { return false }}, nil)
+	if err != nil || result.Selected != 0 || result.Err() != nil {
+		t.Errorf("Messages.BulkDelete without selected messages returned %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,131 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// defaultSMTPPort is used if the inbox has no SMTP ports supporting STARTTLS.
+const defaultSMTPPort = 2525
+
+// Copy copies the message to another inbox of the account. The API provides no copy endpoint,
+// so the message source is fetched and delivered to the target inbox through its SMTP credentials,
+// preserving the headers and attachments. The copy gets a new message ID in the target inbox.
+func (s *MessagesService) Copy(accountID, inboxID, messageID, targetInboxID int) (*Response, error) {
+	msg, res, err := s.Get(accountID, inboxID, messageID)
+	if err != nil {
+		return res, err
+	}
+
+	source, res, err := s.AsRaw(accountID, inboxID, messageID)
+	if err != nil {
+		return res, err
+	}
+
+	inboxes := &InboxesService{client: s.client}
+	target, res, err := inboxes.Get(accountID, targetInboxID)
+	if err != nil {
+		return res, err
+	}
+	if target.Domain == "" || target.Username == "" {
+		return res, fmt.Errorf("inbox %d has no SMTP credentials", targetInboxID)
+	}
+
+	from, to, err := messageEnvelope(msg, source)
+	if err != nil {
+		return res, err
+	}
+
+	send := s.sendMail
+	if send == nil {
+		send = smtp.SendMail
+	}
+	addr := net.JoinHostPort(target.Domain, strconv.Itoa(inboxSMTPPort(target)))
+	auth := smtp.PlainAuth("", target.Username, target.Password, target.Domain)
+	if err := send(addr, auth, from, to, []byte(source)); err != nil {
+		return res, fmt.Errorf("deliver message to inbox %d: %w", targetInboxID, err)
+	}
+
+	return res, nil
+}
+
+// Move copies the message to another inbox of the account and deletes the original one.
+// The original message is kept if the copy fails.
+func (s *MessagesService) Move(accountID, inboxID, messageID, targetInboxID int) (*Response, error) {
+	if res, err := s.Copy(accountID, inboxID, messageID, targetInboxID); err != nil {
+		return res, err
+	}
+	return s.Delete(accountID, inboxID, messageID)
+}
+
+// messageEnvelope returns the SMTP envelope of the message: the original sender
+// and the recipients from the To and Cc headers along with the inbox recipient.
+func messageEnvelope(msg *Message, source string) (string, []string, error) {
+	parsed, err := mail.ReadMessage(strings.NewReader(source))
+	if err != nil {
+		return "", nil, fmt.Errorf("parse message source: %w", err)
+	}
+
+	from := msg.FromEmail
+	if msg.SMTPInfo != nil && msg.SMTPInfo.Data.MailFromAddr != "" {
+		from = msg.SMTPInfo.Data.MailFromAddr
+	}
+	if from == "" {
+		if addr, err := mail.ParseAddress(parsed.Header.Get("From")); err == nil {
+			from = addr.Address
+		}
+	}
+
+	var (
+		to   []string
+		seen = map[string]bool{}
+	)
+	add := func(email string) {
+		key := strings.ToLower(email)
+		if email != "" && !seen[key] {
+			seen[key] = true
+			to = append(to, email)
+		}
+	}
+	for _, header := range []string{"To", "Cc"} {
+		// Missing or malformed headers are skipped.
+		addrs, _ := parsed.Header.AddressList(header)
+		for _, addr := range addrs {
+			add(addr.Address)
+		}
+	}
+	add(msg.ToEmail)
+
+	if len(to) == 0 {
+		return "", nil, errors.New("message has no recipients")
+	}
+
+	return from, to, nil
+}
+
+// preferredSMTPPorts are the STARTTLS ports in order of preference. Port 25 is the last resort,
+// as outbound connections to it are blocked by many networks, e.g. cloud CI runners.
+var preferredSMTPPorts = []int{2525, 587, 25}
+
+// inboxSMTPPort returns the most preferred SMTP port of the inbox, or another one
+// supporting STARTTLS, as the implicit TLS port 465 is not supported by smtp.SendMail.
+func inboxSMTPPort(inbox *Inbox) int {
+	for _, preferred := range preferredSMTPPorts {
+		for _, port := range inbox.SMTPPorts {
+			if port == preferred {
+				return port
+			}
+		}
+	}
+	for _, port := range inbox.SMTPPorts {
+		if port != 465 {
+			return port
+		}
+	}
+	return defaultSMTPPort
+}
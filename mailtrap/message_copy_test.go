@@ -0,0 +1,142 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"reflect"
+	"testing"
+)
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func setupMessageCopy(t *testing.T, mux *http.ServeMux, target *Inbox) (deleted *bool) {
+	deleted = new(bool)
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			*deleted = true
+		}
+		resp, _ := json.Marshal(messageMock(3))
+		fmt.Fprint(w, string(resp))
+	})
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.raw", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, rawMessageMock)
+	})
+	mux.HandleFunc("/accounts/1/inboxes/4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(target)
+		fmt.Fprint(w, string(resp))
+	})
+	return deleted
+}
+
+func TestMessagesService_Copy(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	deleted := setupMessageCopy(t, mux, inboxMock(4))
+
+	var sent []sentMail
+	client.Messages.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{addr: addr, from: from, to: to, msg: string(msg)})
+		return nil
+	}
+
+	if _, err := client.Messages.Copy(1, 2, 3, 4); err != nil {
+		t.Fatalf("Messages.Copy returned error: %v", err)
+	}
+
+	want := []sentMail{{
+		addr: "localhost:2525",
+		from: "john@xample.com",
+		to:   []string{"john@example.com", "mary@xample.com"},
+		msg:  rawMessageMock,
+	}}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("Messages.Copy sent %+v, want %+v", sent, want)
+	}
+	if *deleted {
+		t.Error("Messages.Copy deleted the original message")
+	}
+
+	testNewRequestAndDoFail(t, "Messages.Copy", &client.client, func() (*Response, error) {
+		return client.Messages.Copy(1, 2, 3, 4)
+	})
+}
+
+func TestMessagesService_Copy_failed(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	target := inboxMock(4)
+	target.Username = ""
+	setupMessageCopy(t, mux, target)
+
+	client.Messages.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		t.Error("Messages.Copy delivered the message to inbox without credentials")
+		return nil
+	}
+	if _, err := client.Messages.Copy(1, 2, 3, 4); err == nil {
+		t.Error("Messages.Copy to inbox without credentials, err = nil, want error")
+	}
+
+	if _, err := client.Messages.Copy(1, 2, 3, 5); err == nil {
+		t.Error("Messages.Copy to missing inbox, err = nil, want error")
+	}
+}
+
+func TestMessagesService_Move(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	deleted := setupMessageCopy(t, mux, inboxMock(4))
+
+	sendErr := errors.New("connection refused")
+	client.Messages.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		return sendErr
+	}
+	if _, err := client.Messages.Move(1, 2, 3, 4); !errors.Is(err, sendErr) {
+		t.Errorf("Messages.Move returned error %v, want %v", err, sendErr)
+	}
+	if *deleted {
+		t.Error("Messages.Move deleted the original message after failed copy")
+	}
+
+	client.Messages.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		return nil
+	}
+	if _, err := client.Messages.Move(1, 2, 3, 4); err != nil {
+		t.Errorf("Messages.Move returned error: %v", err)
+	}
+	if !*deleted {
+		t.Error("Messages.Move did not delete the original message")
+	}
+}
+
+func TestInboxSMTPPort(t *testing.T) {
+	tests := []struct {
+		ports []int
+		want  int
+	}{
+		{[]int{25, 465, 587, 2525}, 2525},
+		{[]int{25, 465, 587}, 587},
+		{[]int{25, 465}, 25},
+		{[]int{465, 2526}, 2526},
+		{[]int{465, 587}, 587},
+		{[]int{465}, defaultSMTPPort},
+		{nil, defaultSMTPPort},
+	}
+	for _, tt := range tests {
+		if got := inboxSMTPPort(&Inbox{SMTPPorts: tt.ports}); got != tt.want {
+			t.Errorf("inboxSMTPPort(%v) = %d, want %d", tt.ports, got, tt.want)
+		}
+	}
+}
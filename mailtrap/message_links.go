@@ -0,0 +1,287 @@
+package mailtrap
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Link represents a link found in the HTML body of a message.
+type Link struct {
+	// Href is the href attribute value with HTML entities unescaped.
+	Href string
+	// URL is the link destination, i.e. Href with tracking redirects unwrapped.
+	URL string
+	// Text is the anchor text with tags stripped and whitespace collapsed.
+	Text string
+}
+
+// Links represents the links of a message in document order.
+type Links []*Link
+
+// trackingURLParams are the query parameters tracking redirects usually pass the destination in.
+// Generic names such as "q" or "to" are left out, as ordinary links use them for other URLs,
+// e.g. a search query.
+var trackingURLParams = []string{
+	"url", "u", "redirect", "redirect_url", "redirect_uri", "redirect_to",
+	"target", "dest", "destination",
+}
+
+// maxTrackingRedirects limits unwrapping of nested tracking redirects.
+const maxTrackingRedirects = 5
+
+// DefaultCodePattern matches the numeric codes of 4 to 8 digits, e.g. one-time passwords.
+var DefaultCodePattern = regexp.MustCompile(`\b\d{4,8}\b`)
+
+var (
+	htmlCommentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagRegexp     = regexp.MustCompile(`(?s)<[^>]*>`)
+	anchorOpenRegexp  = regexp.MustCompile(`(?i)<a[\s>]`)
+	anchorCloseRegexp = regexp.MustCompile(`(?i)</a\s*>`)
+)
+
+// ExtractLinks returns the links of the anchor elements in the HTML document.
+// The anchors without the href attribute are skipped.
+func ExtractLinks(document string) Links {
+	document = htmlCommentRegexp.ReplaceAllString(document, "")
+
+	var links Links
+	for {
+		start, tagEnd, ok := findAnchorTag(document)
+		if !ok {
+			break
+		}
+		attrs := document[start+len("<a") : tagEnd]
+		document = document[tagEnd+1:]
+
+		// The anchor text ends at the closing tag or the next anchor, as anchors can't be nested.
+		end := len(document)
+		if c := anchorCloseRegexp.FindStringIndex(document); c != nil {
+			end = c[0]
+		}
+		if next, _, ok := findAnchorTag(document); ok && next < end {
+			end = next
+		}
+		text := document[:end]
+		document = document[end:]
+
+		href, ok := parseTagAttrs(attrs)["href"]
+		if !ok {
+			continue
+		}
+		href = strings.TrimSpace(html.UnescapeString(href))
+		links = append(links, &Link{
+			Href: href,
+			URL:  UnwrapTrackingURL(href),
+			Text: collapseSpace(html.UnescapeString(htmlTagRegexp.ReplaceAllString(text, " "))),
+		})
+	}
+
+	return links
+}
+
+// findAnchorTag returns the positions of the next anchor start tag in the document
+// and of the '>' ending it. The '>' within quoted attribute values doesn't end the tag.
+func findAnchorTag(document string) (start, end int, ok bool) {
+	loc := anchorOpenRegexp.FindStringIndex(document)
+	if loc == nil {
+		return 0, 0, false
+	}
+	start = loc[0]
+
+	s := document[start+len("<a"):]
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '>':
+			return start, start + len("<a") + i, true
+		case '=':
+			j := i + 1
+			for j < len(s) && strings.IndexByte(" \t\r\n\f", s[j]) >= 0 {
+				j++
+			}
+			if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+				k := strings.IndexByte(s[j+1:], s[j])
+				if k < 0 {
+					return 0, 0, false
+				}
+				i = j + 1 + k
+			}
+		}
+	}
+	// The tag is not terminated.
+	return 0, 0, false
+}
+
+// parseTagAttrs parses the attributes of the tag into a map with lower-cased names.
+// The values are not unescaped.
+func parseTagAttrs(s string) map[string]string {
+	attrs := map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \t\r\n\f/")
+		if s == "" {
+			return attrs
+		}
+
+		i := strings.IndexAny(s, " \t\r\n\f/=")
+		if i < 0 {
+			i = len(s)
+		}
+		name := strings.ToLower(s[:i])
+		s = strings.TrimLeft(s[i:], " \t\r\n\f")
+		if !strings.HasPrefix(s, "=") {
+			if _, ok := attrs[name]; !ok {
+				attrs[name] = ""
+			}
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n\f")
+
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(s, " \t\r\n\f")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		// The first occurrence of an attribute wins, as in browsers.
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = value
+		}
+	}
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// UnwrapTrackingURL returns the destination of the click tracking redirect URL,
+// i.e. the absolute http(s) URL of another host passed in one of its query parameters
+// such as "url" or "redirect".
+// Nested redirects are unwrapped as well. Other URLs are returned unchanged.
+func UnwrapTrackingURL(rawURL string) string {
+	for i := 0; i < maxTrackingRedirects; i++ {
+		dest, ok := trackingDestination(rawURL)
+		if !ok {
+			break
+		}
+		rawURL = dest
+	}
+	return rawURL
+}
+
+func trackingDestination(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || !isHTTPURL(u) {
+		return "", false
+	}
+
+	query := u.Query()
+	for _, param := range trackingURLParams {
+		for _, v := range query[param] {
+			// Redirects within the same host, e.g. after login, are not tracking redirects.
+			if dest, err := url.Parse(v); err == nil && isHTTPURL(dest) && !strings.EqualFold(dest.Host, u.Host) {
+				return v, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func isHTTPURL(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ByText returns the first link whose text contains the given text, ignoring case.
+func (l Links) ByText(text string) *Link {
+	text = strings.ToLower(collapseSpace(text))
+	for _, link := range l {
+		if strings.Contains(strings.ToLower(link.Text), text) {
+			return link
+		}
+	}
+	return nil
+}
+
+// Match returns the first link whose text, URL or href matches the regular expression.
+func (l Links) Match(re *regexp.Regexp) *Link {
+	for _, link := range l {
+		if re.MatchString(link.Text) || re.MatchString(link.URL) || re.MatchString(link.Href) {
+			return link
+		}
+	}
+	return nil
+}
+
+// URLs returns the destination URLs of the links.
+func (l Links) URLs() []string {
+	urls := make([]string, len(l))
+	for i, link := range l {
+		urls[i] = link.URL
+	}
+	return urls
+}
+
+// ExtractCodes returns the codes matched by the pattern in the text, in order of appearance.
+// If the pattern has a capturing group, the first group is returned instead of the whole match.
+// DefaultCodePattern is used if the pattern is nil.
+func ExtractCodes(text string, pattern *regexp.Regexp) []string {
+	if pattern == nil {
+		pattern = DefaultCodePattern
+	}
+
+	var codes []string
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		code := m[0]
+		if len(m) > 1 {
+			code = m[1]
+		}
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// ExtractCode returns the first code matched by the pattern in the text,
+// or an error if there is none. DefaultCodePattern is used if the pattern is nil.
+func ExtractCode(text string, pattern *regexp.Regexp) (string, error) {
+	codes := ExtractCodes(text, pattern)
+	if len(codes) == 0 {
+		if pattern == nil {
+			pattern = DefaultCodePattern
+		}
+		return "", fmt.Errorf("no code matching %q found", pattern)
+	}
+	return codes[0], nil
+}
+
+// Links returns the links of the message HTML body.
+func (s *MessagesService) Links(accountID, inboxID, messageID int) (Links, *Response, error) {
+	body, res, err := s.AsHTML(accountID, inboxID, messageID)
+	if err != nil {
+		return nil, res, err
+	}
+	return ExtractLinks(body), res, nil
+}
+
+// Codes returns the codes matched by the pattern in the message text body.
+// DefaultCodePattern is used if the pattern is nil.
+func (s *MessagesService) Codes(
+	accountID, inboxID, messageID int,
+	pattern *regexp.Regexp,
+) ([]string, *Response, error) {
+	body, res, err := s.AsText(accountID, inboxID, messageID)
+	if err != nil {
+		return nil, res, err
+	}
+	return ExtractCodes(body, pattern), res, nil
+}
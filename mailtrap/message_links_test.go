@@ -0,0 +1,179 @@
+package mailtrap
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+const linksHTMLMock = `<!doctype html>
+<html>
+<body>
+	<!-- <a href="https://example.com/commented">Commented</a> -->
+	<p>Hello, John!</p>
+	<A class="button" HREF="https://click.tracker.com/ls/click?upn=abc&amp;url=https%3A%2F%2Fapp.example.com%2Freset%3Ftoken%3Dxyz%26id%3D1">
+		<span>Reset&nbsp;your <b>password</b></span>
+	</A>
+	<a href='https://app.example.com/login?redirect=https://app.example.com/account'>Log in</a>
+	<a name="anchor">No href</a>
+	<a href=https://example.com/unsubscribe target=_blank>Unsubscribe
+	<a href="mailto:support@example.com">Contact &amp; support</a>
+</body>
+</html>`
+
+func TestExtractLinks(t *testing.T) {
+	links := ExtractLinks(linksHTMLMock)
+
+	want := Links{
+		{
+			Href: "https://click.tracker.com/ls/click?upn=abc&url=https%3A%2F%2Fapp.example.com%2Freset%3Ftoken%3Dxyz%26id%3D1",
+			URL:  "https://app.example.com/reset?token=xyz&id=1",
+			Text: "Reset your password",
+		},
+		{
+			Href: "https://app.example.com/login?redirect=https://app.example.com/account",
+			URL:  "https://app.example.com/login?redirect=https://app.example.com/account",
+			Text: "Log in",
+		},
+		{
+			Href: "https://example.com/unsubscribe",
+			URL:  "https://example.com/unsubscribe",
+			Text: "Unsubscribe",
+		},
+		{
+			Href: "mailto:support@example.com",
+			URL:  "mailto:support@example.com",
+			Text: "Contact & support",
+		},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("ExtractLinks returned %+v, want %+v", links, want)
+	}
+
+	if link := links.ByText("reset YOUR"); link != links[0] {
+		t.Errorf("Links.ByText returned %+v, want %+v", link, links[0])
+	}
+	if link := links.ByText("missing"); link != nil {
+		t.Errorf("Links.ByText returned %+v, want nil", link)
+	}
+	if link := links.Match(regexp.MustCompile(`token=\w+`)); link != links[0] {
+		t.Errorf("Links.Match returned %+v, want %+v", link, links[0])
+	}
+	if link := links.Match(regexp.MustCompile(`(?i)^unsubscribe$`)); link != links[2] {
+		t.Errorf("Links.Match returned %+v, want %+v", link, links[2])
+	}
+	if urls := links.URLs(); len(urls) != 4 || urls[0] != want[0].URL {
+		t.Errorf("Links.URLs returned %v", urls)
+	}
+
+	links = ExtractLinks(`<a title='a > b' href="https://x/?a>b">Text</a><a href=https://y/>Next</a>`)
+	want = Links{
+		{Href: "https://x/?a>b", URL: "https://x/?a>b", Text: "Text"},
+		{Href: "https://y/", URL: "https://y/", Text: "Next"},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("ExtractLinks with '>' in attributes returned %+v, want %+v", links, want)
+	}
+
+	if links := ExtractLinks("<p>No links</p>"); links != nil {
+		t.Errorf("ExtractLinks without links returned %+v, want nil", links)
+	}
+}
+
+func TestUnwrapTrackingURL(t *testing.T) {
+	tests := map[string]string{
+		"https://t.example.net/r?u=https%3A%2F%2Fexample.com%2Fa":                                                      "https://example.com/a",
+		"https://t1.example.net/r?redirect=https%3A%2F%2Ft2.example.net%2F%3Ftarget%3Dhttps%253A%252F%252Fexample.com": "https://example.com",
+		"https://example.com/login?redirect=https://example.com/account":                                               "https://example.com/login?redirect=https://example.com/account",
+		"https://t.example.net/r?url=javascript:alert(1)":                                                              "https://t.example.net/r?url=javascript:alert(1)",
+		"/relative?url=https://example.com":                                                                            "/relative?url=https://example.com",
+		"%zz":                                                                                                          "%zz",
+		"https://www.google.com/search?q=https://evil.com":                                                             "https://www.google.com/search?q=https://evil.com",
+		"https://example.com/share?to=https://evil.com&link=https://evil.com":                                          "https://example.com/share?to=https://evil.com&link=https://evil.com",
+	}
+	for rawURL, want := range tests {
+		if got := UnwrapTrackingURL(rawURL); got != want {
+			t.Errorf("UnwrapTrackingURL(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestExtractCodes(t *testing.T) {
+	text := "Your verification code is 482913. It expires in 10 minutes.\nOrder #20240101, PIN: 0042"
+
+	if codes := ExtractCodes(text, nil); !reflect.DeepEqual(codes, []string{"482913", "20240101", "0042"}) {
+		t.Errorf("ExtractCodes with default pattern returned %v", codes)
+	}
+
+	pattern := regexp.MustCompile(`PIN: (\d+)`)
+	if codes := ExtractCodes(text, pattern); !reflect.DeepEqual(codes, []string{"0042"}) {
+		t.Errorf("ExtractCodes with capturing group returned %v", codes)
+	}
+
+	code, err := ExtractCode(text, regexp.MustCompile(`code is (\d{6})`))
+	if err != nil || code != "482913" {
+		t.Errorf("ExtractCode returned %q, %v", code, err)
+	}
+	if _, err := ExtractCode("No code here", nil); err == nil {
+		t.Error("ExtractCode without code, err = nil, want error")
+	}
+}
+
+func TestMessagesService_Links(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.html", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "text/html")
+		fmt.Fprint(w, linksHTMLMock)
+	})
+
+	links, _, err := client.Messages.Links(1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.Links returned error: %v", err)
+	}
+	if link := links.ByText("reset your password"); link == nil || link.URL != "https://app.example.com/reset?token=xyz&id=1" {
+		t.Errorf("Messages.Links ByText returned %+v", link)
+	}
+	if link := links.ByText("log in"); link == nil || link.URL != "https://app.example.com/login?redirect=https://app.example.com/account" {
+		t.Errorf("Messages.Links ByText returned %+v", link)
+	}
+
+	testNewRequestAndDoFail(t, "Messages.Links", &client.client, func() (*Response, error) {
+		links, resp, err := client.Messages.Links(1, 2, 3)
+		if links != nil {
+			t.Errorf("Messages.Links client.BaseURL.Host=%v links=%#v, want nil", client.baseURL.Host, links)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_Codes(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.txt", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "text/plain")
+		fmt.Fprint(w, "Your one-time password is 731904.")
+	})
+
+	codes, _, err := client.Messages.Codes(1, 2, 3, nil)
+	if err != nil {
+		t.Fatalf("Messages.Codes returned error: %v", err)
+	}
+	if !reflect.DeepEqual(codes, []string{"731904"}) {
+		t.Errorf("Messages.Codes returned %v", codes)
+	}
+
+	testNewRequestAndDoFail(t, "Messages.Codes", &client.client, func() (*Response, error) {
+		codes, resp, err := client.Messages.Codes(1, 2, 3, nil)
+		if codes != nil {
+			t.Errorf("Messages.Codes client.BaseURL.Host=%v codes=%#v, want nil", client.baseURL.Host, codes)
+		}
+		return resp, err
+	})
+}
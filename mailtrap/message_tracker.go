@@ -0,0 +1,222 @@
+package mailtrap
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MessageStatusSent is the status of a tracked message without webhook events.
+const MessageStatusSent = "sent"
+
+// MessageStatusEvent represents an entry of the message status timeline.
+type MessageStatusEvent struct {
+	Event     string
+	Email     string
+	EventID   string
+	Timestamp time.Time
+	// Details contains the event response, reason or clicked URL, if any.
+	Details string
+}
+
+// TrackedMessage represents a sent message and its delivery status.
+type TrackedMessage struct {
+	MessageID  string
+	Recipients []string
+	Category   string
+	CustomVars map[string]string
+	SentAt     time.Time
+
+	// Status is the type of the latest event, or MessageStatusSent.
+	Status   string
+	Timeline []MessageStatusEvent
+}
+
+// MessageTracker correlates webhook events with the sent messages.
+//
+// Register RecordSend with SendingClient.AddSendHook and pass the webhook
+// events to Write, e.g. by adding the tracker to a FanOut.
+// MessageTracker is safe for concurrent use.
+type MessageTracker struct {
+	mu       sync.RWMutex
+	messages map[string]*TrackedMessage
+
+	// now returns the send time, it's replaced in tests.
+	now func() time.Time
+}
+
+var _ Sink = &MessageTracker{}
+
+// NewMessageTracker creates an empty message tracker.
+func NewMessageTracker() *MessageTracker {
+	return &MessageTracker{
+		messages: make(map[string]*TrackedMessage),
+		now:      time.Now,
+	}
+}
+
+// RecordSend records the sent messages. It has the SendHook signature.
+//
+// When the response contains a message ID per recipient, each message is
+// associated with its recipient, otherwise with all recipients of the request.
+func (t *MessageTracker) RecordSend(request *SendEmailRequest, response *SendEmailResponse) {
+	if request == nil || response == nil {
+		return
+	}
+
+	var recipients []string
+	for _, addresses := range [][]EmailAddress{request.To, request.Cc, request.Bcc} {
+		for _, a := range addresses {
+			recipients = append(recipients, a.Email)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sentAt := t.now().UTC()
+	for i, id := range response.MessageIDs {
+		m := t.message(id)
+		if len(recipients) == len(response.MessageIDs) {
+			m.Recipients = []string{recipients[i]}
+		} else {
+			m.Recipients = append([]string(nil), recipients...)
+		}
+		m.Category = request.Category
+		m.CustomVars = copyStringMap(request.CustomVars)
+		m.SentAt = sentAt
+	}
+}
+
+// Write adds the webhook events to the timelines of their messages.
+// Events of unknown messages start a new timeline. Duplicate events are ignored.
+func (t *MessageTracker) Write(events []Event) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, e := range events {
+		if e.MessageID == "" {
+			continue
+		}
+		m := t.message(e.MessageID)
+		if e.EventID != "" && hasStatusEvent(m.Timeline, e.EventID) {
+			continue
+		}
+
+		details := e.Response
+		if e.Reason != "" {
+			details = e.Reason
+		}
+		if e.URL != "" {
+			details = e.URL
+		}
+
+		m.Timeline = append(m.Timeline, MessageStatusEvent{
+			Event:     e.Event,
+			Email:     e.Email,
+			EventID:   e.EventID,
+			Timestamp: e.Timestamp.UTC(),
+			Details:   details,
+		})
+		sort.SliceStable(m.Timeline, func(i, j int) bool {
+			return m.Timeline[i].Timestamp.Before(m.Timeline[j].Timestamp)
+		})
+		m.Status = m.Timeline[len(m.Timeline)-1].Event
+	}
+
+	return nil
+}
+
+// Get returns the tracked message by its ID.
+func (t *MessageTracker) Get(messageID string) (*TrackedMessage, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	m, ok := t.messages[messageID]
+	if !ok {
+		return nil, false
+	}
+	return m.copy(), true
+}
+
+// ByRecipient returns the messages sent to the email address, ordered by send time.
+func (t *MessageTracker) ByRecipient(email string) []*TrackedMessage {
+	email = normalizeEmail(email)
+	return t.find(func(m *TrackedMessage) bool {
+		for _, r := range m.Recipients {
+			if normalizeEmail(r) == email {
+				return true
+			}
+		}
+		for _, e := range m.Timeline {
+			if normalizeEmail(e.Email) == email {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ByCategory returns the messages of the category, ordered by send time.
+func (t *MessageTracker) ByCategory(category string) []*TrackedMessage {
+	return t.find(func(m *TrackedMessage) bool {
+		return m.Category == category
+	})
+}
+
+func (t *MessageTracker) find(match func(m *TrackedMessage) bool) []*TrackedMessage {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var messages []*TrackedMessage
+	for _, m := range t.messages {
+		if match(m) {
+			messages = append(messages, m.copy())
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if !messages[i].SentAt.Equal(messages[j].SentAt) {
+			return messages[i].SentAt.Before(messages[j].SentAt)
+		}
+		return messages[i].MessageID < messages[j].MessageID
+	})
+	return messages
+}
+
+// message returns the tracked message, creating it if necessary.
+func (t *MessageTracker) message(id string) *TrackedMessage {
+	m, ok := t.messages[id]
+	if !ok {
+		m = &TrackedMessage{MessageID: id, Status: MessageStatusSent}
+		t.messages[id] = m
+	}
+	return m
+}
+
+func (m *TrackedMessage) copy() *TrackedMessage {
+	mc := *m
+	mc.Recipients = append([]string(nil), m.Recipients...)
+	mc.CustomVars = copyStringMap(m.CustomVars)
+	mc.Timeline = append([]MessageStatusEvent(nil), m.Timeline...)
+	return &mc
+}
+
+func hasStatusEvent(timeline []MessageStatusEvent, eventID string) bool {
+	for _, e := range timeline {
+		if e.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	mc := make(map[string]string, len(m))
+	for k, v := range m {
+		mc[k] = v
+	}
+	return mc
+}
@@ -0,0 +1,95 @@
+package mailtrap
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMessageTracker(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["msg-1","msg-2","msg-3","msg-4"]}`)
+	})
+
+	sentAt := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	tracker := NewMessageTracker()
+	tracker.now = func() time.Time { return sentAt }
+	client.AddSendHook(tracker.RecordSend)
+
+	if _, _, err := client.Send(emailRequestMock()); err != nil {
+		t.Fatalf("SendEmail.Send returned error: %v", err)
+	}
+
+	err := tracker.Write([]Event{
+		{Event: EventOpen, Email: "mike@example.com", MessageID: "msg-2", EventID: "e-2", Timestamp: unixTimestamp(1700000200)},
+		{Event: EventDelivery, Email: "mike@example.com", MessageID: "msg-2", EventID: "e-1", Timestamp: unixTimestamp(1700000100)},
+		{Event: EventDelivery, Email: "mike@example.com", MessageID: "msg-2", EventID: "e-1", Timestamp: unixTimestamp(1700000100)},
+		{Event: EventBounce, Email: "other@example.com", MessageID: "unknown", Response: "550", Timestamp: unixTimestamp(1700000300)},
+	})
+	if err != nil {
+		t.Fatalf("MessageTracker.Write returned error: %v", err)
+	}
+
+	msg, ok := tracker.Get("msg-2")
+	if !ok {
+		t.Fatal("MessageTracker.Get returned no message")
+	}
+	expected := &TrackedMessage{
+		MessageID:  "msg-2",
+		Recipients: []string{"mike@example.com"},
+		Category:   "API Client",
+		CustomVars: map[string]string{"user_id": "1", "batch_id": "2"},
+		SentAt:     sentAt,
+		Status:     EventOpen,
+		Timeline: []MessageStatusEvent{
+			{Event: EventDelivery, Email: "mike@example.com", EventID: "e-1", Timestamp: time.Unix(1700000100, 0).UTC()},
+			{Event: EventOpen, Email: "mike@example.com", EventID: "e-2", Timestamp: time.Unix(1700000200, 0).UTC()},
+		},
+	}
+	if !reflect.DeepEqual(msg, expected) {
+		t.Errorf("MessageTracker.Get returned %+v, expected %+v", msg, expected)
+	}
+
+	if m, _ := tracker.Get("msg-1"); m.Status != MessageStatusSent {
+		t.Errorf("MessageTracker.Get status is %q, want %q", m.Status, MessageStatusSent)
+	}
+	if m, _ := tracker.Get("unknown"); m.Status != EventBounce || m.Timeline[0].Details != "550" {
+		t.Errorf("MessageTracker.Get for unknown message returned %+v", m)
+	}
+
+	byRecipient := tracker.ByRecipient("Mike@Example.com")
+	if len(byRecipient) != 1 || byRecipient[0].MessageID != "msg-2" {
+		t.Errorf("MessageTracker.ByRecipient returned %+v", byRecipient)
+	}
+	if got := tracker.ByRecipient("other@example.com"); len(got) != 1 {
+		t.Errorf("MessageTracker.ByRecipient by event email returned %+v", got)
+	}
+
+	byCategory := tracker.ByCategory("API Client")
+	if len(byCategory) != 4 {
+		t.Fatalf("MessageTracker.ByCategory returned %d messages, want 4", len(byCategory))
+	}
+	for i, m := range byCategory {
+		if want := fmt.Sprintf("msg-%d", i+1); m.MessageID != want {
+			t.Errorf("MessageTracker.ByCategory[%d] is %s, want %s", i, m.MessageID, want)
+		}
+	}
+}
+
+func TestMessageTracker_RecordSend_sharedRecipients(t *testing.T) {
+	tracker := NewMessageTracker()
+	tracker.RecordSend(emailRequestMock(), &SendEmailResponse{Success: true, MessageIDs: []string{"msg-1"}})
+
+	msg, _ := tracker.Get("msg-1")
+	expected := []string{"johndoe@example.com", "mike@example.com", "info@example.com", "dontreply@example.com"}
+	if !reflect.DeepEqual(msg.Recipients, expected) {
+		t.Errorf("MessageTracker recipients are %v, expected %v", msg.Recipients, expected)
+	}
+
+	tracker.RecordSend(nil, nil)
+}
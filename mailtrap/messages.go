@@ -1,11 +1,18 @@
 package mailtrap
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/mail"
-	"time"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"regexp"
+	"strconv"
 )
 
 type MessagesServiceContract interface {
@@ -15,15 +22,31 @@ type MessagesServiceContract interface {
 	Delete(accountID, inboxID, messageID int) (*Response, error)
 	Forward(accountID, inboxID, messageID int, email string) (*Response, error)
 	SpamReport(accountID, inboxID, messageID int) (*SpamReport, *Response, error)
+	Headers(accountID, inboxID, messageID int) (MessageHeaders, *Response, error)
+	HTMLAnalysis(accountID, inboxID, messageID int) (*HTMLAnalysis, *Response, error)
+	BlacklistsReport(accountID, inboxID, messageID int) (*BlacklistsReport, *Response, error)
 	AsRaw(accountID, inboxID, messageID int) (string, *Response, error)
 	AsText(accountID, inboxID, messageID int) (string, *Response, error)
 	AsHTML(accountID, inboxID, messageID int) (string, *Response, error)
 	AsHTMLSource(accountID, inboxID, messageID int) (string, *Response, error)
 	AsEML(accountID, inboxID, messageID int) (string, *Response, error)
+	Parsed(accountID, inboxID, messageID int) (*ParsedMessage, *Response, error)
+	Body(accountID, inboxID, messageID int, format BodyFormat) (io.ReadCloser, *Response, error)
+	WriteBodyTo(w io.Writer, accountID, inboxID, messageID int, format BodyFormat) (int64, *Response, error)
+	Copy(accountID, inboxID, messageID, targetInboxID int) (*Response, error)
+	Move(accountID, inboxID, messageID, targetInboxID int) (*Response, error)
+	BulkDelete(sel *MessageSelector, opts *BulkOptions) (*BulkResult, error)
+	BulkUpdate(sel *MessageSelector, updateReq *UpdateMessageRequest, opts *BulkOptions) (*BulkResult, error)
+	BulkForward(sel *MessageSelector, email string, opts *BulkOptions) (*BulkResult, error)
+	Links(accountID, inboxID, messageID int) (Links, *Response, error)
+	Codes(accountID, inboxID, messageID int, pattern *regexp.Regexp) ([]string, *Response, error)
 }
 
 type MessagesService struct {
 	client *client
+
+	// sendMail delivers the copied messages, smtp.SendMail is used if nil.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
 }
 
 var _ MessagesServiceContract = &MessagesService{}
@@ -33,15 +56,15 @@ type Message struct {
 	ID                   int              `json:"id"`
 	InboxID              int              `json:"inbox_id"`
 	Subject              string           `json:"subject"`
-	SentAt               time.Time        `json:"sent_at"`
+	SentAt               Timestamp        `json:"sent_at"`
 	FromEmail            string           `json:"from_email"`
 	FromName             string           `json:"from_name"`
 	ToEmail              string           `json:"to_email"`
 	ToName               string           `json:"to_name"`
 	EmailSize            int              `json:"email_size"`
 	IsRead               bool             `json:"is_read"`
-	CreatedAt            time.Time        `json:"created_at"`
-	UpdatedAt            time.Time        `json:"updated_at"`
+	CreatedAt            Timestamp        `json:"created_at"`
+	UpdatedAt            Timestamp        `json:"updated_at"`
 	HTMLBodySize         int              `json:"html_body_size"`
 	TextBodySize         int              `json:"text_body_size"`
 	HumanSize            string           `json:"human_size"`
@@ -66,25 +89,129 @@ type MessageSMTPInfo struct {
 // SpamReport represents Mailtrap message spam analysis report.
 type SpamReport struct {
 	Report struct {
-		ResponseCode    int           `json:"ResponseCode"`
-		ResponseMessage string        `json:"ResponseMessage"`
-		ResponseVersion string        `json:"ResponseVersion"`
-		Score           float64       `json:"Score"`
-		Spam            bool          `json:"Spam"`
-		Threshold       float64       `json:"Threshold"`
-		Details         []interface{} `json:"Details"` // string or null
+		ResponseCode    int         `json:"ResponseCode"`
+		ResponseMessage string      `json:"ResponseMessage"`
+		ResponseVersion string      `json:"ResponseVersion"`
+		Score           float64     `json:"Score"`
+		Spam            bool        `json:"Spam"`
+		Threshold       float64     `json:"Threshold"`
+		Details         []*SpamRule `json:"Details"`
 	} `json:"report"`
 }
 
+// BodyFormat is the format of the message body.
+type BodyFormat string
+
+const (
+	BodyRaw        BodyFormat = "raw"
+	BodyText       BodyFormat = "txt"
+	BodyHTML       BodyFormat = "html"
+	BodyHTMLSource BodyFormat = "htmlsource"
+	BodyEML        BodyFormat = "eml"
+)
+
+// bodyAcceptHeaders maps the body formats to their content types.
+var bodyAcceptHeaders = map[BodyFormat]string{
+	BodyRaw:        "text/plain",
+	BodyText:       "text/plain",
+	BodyHTML:       "text/html",
+	BodyHTMLSource: "text/html",
+	BodyEML:        "message/rfc822",
+}
+
+// MessageHeaders represents the message headers keyed by the canonical header name.
+// The values are decoded from RFC 2047 encoded-words.
+type MessageHeaders map[string][]string
+
+// Get returns the first value of the header, or an empty string.
+func (h MessageHeaders) Get(key string) string {
+	if v := h[textproto.CanonicalMIMEHeaderKey(key)]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Values returns all values of the header.
+func (h MessageHeaders) Values(key string) []string {
+	return h[textproto.CanonicalMIMEHeaderKey(key)]
+}
+
+// HTMLAnalysis represents Mailtrap message HTML analysis report.
+type HTMLAnalysis struct {
+	// status can return success, error
+	Status string               `json:"status"`
+	Errors []*HTMLAnalysisError `json:"errors"`
+}
+
+// HTMLAnalysisError represents HTML or CSS feature not supported by the email clients.
+type HTMLAnalysisError struct {
+	// ErrorLine is the line of the HTML source.
+	ErrorLine    int                    `json:"error_line"`
+	RuleName     string                 `json:"rule_name"`
+	EmailClients HTMLAnalysisClientList `json:"email_clients"`
+}
+
+// HTMLAnalysisClientList represents the email clients not supporting the feature.
+type HTMLAnalysisClientList struct {
+	Desktop []string `json:"desktop"`
+	Mobile  []string `json:"mobile"`
+	Web     []string `json:"web"`
+}
+
+// HasErrors reports whether the HTML uses features not supported by any email client.
+func (a *HTMLAnalysis) HasErrors() bool {
+	return len(a.Errors) > 0
+}
+
+// BlacklistsReport represents Mailtrap message blacklists report.
+type BlacklistsReport struct {
+	// IP and Domain are the sending IP address and domain checked against the blacklists.
+	IP         string       `json:"ip"`
+	Domain     string       `json:"domain"`
+	Blacklists []*Blacklist `json:"blacklists"`
+}
+
+// Blacklist represents the result of a blacklist check.
+type Blacklist struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	InBlacklist bool   `json:"in_black_list"`
+}
+
+// Listed returns the blacklists listing the sending IP address or domain.
+func (r *BlacklistsReport) Listed() []*Blacklist {
+	var listed []*Blacklist
+	for _, b := range r.Blacklists {
+		if b.InBlacklist {
+			listed = append(listed, b)
+		}
+	}
+	return listed
+}
+
+// Clean reports whether the sending IP address and domain are not listed in any blacklist.
+func (r *BlacklistsReport) Clean() bool {
+	return len(r.Listed()) == 0
+}
+
 // List returns all messages in inboxs.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/a80869adf4489-get-messages
 func (s *MessagesService) List(accountID, inboxID int) ([]*Message, *Response, error) {
+	return s.listPage(accountID, inboxID, 0)
+}
+
+// listPage returns the page of messages following the message with lastID.
+// The first page is returned if lastID is 0.
+func (s *MessagesService) listPage(accountID, inboxID, lastID int) ([]*Message, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages", accountID, inboxID)
 	req, err := s.client.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	if lastID > 0 {
+		req.URL.RawQuery = url.Values{"last_id": {strconv.Itoa(lastID)}}.Encode()
+	}
 
 	var msg []*Message
 	res, err := s.client.Do(req, &msg)
@@ -201,6 +328,101 @@ func (s *MessagesService) SpamReport(accountID, inboxID, messageID int) (*SpamRe
 	return report, res, nil
 }
 
+// Headers returns the mail headers of the message.
+func (s *MessagesService) Headers(accountID, inboxID, messageID int) (MessageHeaders, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/mail_headers", accountID, inboxID, messageID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var payload struct {
+		Headers map[string]json.RawMessage `json:"headers"`
+	}
+	res, err := s.client.Do(req, &payload)
+	if err != nil {
+		return nil, res, err
+	}
+
+	headers, err := decodeMessageHeaders(payload.Headers)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return headers, res, nil
+}
+
+// decodeMessageHeaders decodes the header values, which are either a string or an array of strings.
+func decodeMessageHeaders(raw map[string]json.RawMessage) (MessageHeaders, error) {
+	dec := new(mime.WordDecoder)
+	headers := make(MessageHeaders, len(raw))
+	for k, v := range raw {
+		var values []string
+		if err := json.Unmarshal(v, &values); err != nil {
+			var value *string
+			if err := json.Unmarshal(v, &value); err != nil {
+				return nil, fmt.Errorf("invalid %q header value: %w", k, err)
+			}
+			if value == nil {
+				continue
+			}
+			values = []string{*value}
+		}
+
+		key := textproto.CanonicalMIMEHeaderKey(k)
+		for _, value := range values {
+			if decoded, err := dec.DecodeHeader(value); err == nil {
+				value = decoded
+			}
+			headers[key] = append(headers[key], value)
+		}
+	}
+
+	return headers, nil
+}
+
+// HTMLAnalysis returns the report of the HTML and CSS features of the message
+// not supported by the email clients.
+func (s *MessagesService) HTMLAnalysis(accountID, inboxID, messageID int) (*HTMLAnalysis, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/analyze", accountID, inboxID, messageID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var payload struct {
+		Report *HTMLAnalysis `json:"report"`
+	}
+	res, err := s.client.Do(req, &payload)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return payload.Report, res, nil
+}
+
+// BlacklistsReport returns the report of the blacklists checked for the message
+// sending IP address and domain. The report is available if Message.BlacklistsReportInfo is true.
+func (s *MessagesService) BlacklistsReport(
+	accountID, inboxID, messageID int,
+) (*BlacklistsReport, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/blacklists_report", accountID, inboxID, messageID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var payload struct {
+		Report *BlacklistsReport `json:"report"`
+	}
+	res, err := s.client.Do(req, &payload)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return payload.Report, res, nil
+}
+
 // AsRaw returns raw email body.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
@@ -241,6 +463,59 @@ func (s *MessagesService) AsEML(accountID, inboxID, messageID int) (string, *Res
 	return s.makeRequest(u, http.MethodGet, "message/rfc822")
 }
 
+// Body returns the message body in the format as a stream, without buffering it in memory.
+// The caller must close the returned body.
+func (s *MessagesService) Body(
+	accountID, inboxID, messageID int,
+	format BodyFormat,
+) (io.ReadCloser, *Response, error) {
+	accept, ok := bodyAcceptHeaders[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid body format %q", format)
+	}
+
+	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.%s", accountID, inboxID, messageID, format)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	return s.client.doStream(req)
+}
+
+// WriteBodyTo copies the message body in the format to w and returns the number of written bytes.
+func (s *MessagesService) WriteBodyTo(
+	w io.Writer,
+	accountID, inboxID, messageID int,
+	format BodyFormat,
+) (int64, *Response, error) {
+	body, res, err := s.Body(accountID, inboxID, messageID, format)
+	if err != nil {
+		return 0, res, err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, body)
+	return n, res, err
+}
+
+// Parsed returns the message parsed into the MIME tree.
+func (s *MessagesService) Parsed(accountID, inboxID, messageID int) (*ParsedMessage, *Response, error) {
+	body, res, err := s.Body(accountID, inboxID, messageID, BodyRaw)
+	if err != nil {
+		return nil, res, err
+	}
+	defer body.Close()
+
+	msg, err := ParseMessage(body)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return msg, res, nil
+}
+
 func (s *MessagesService) makeRequest(endpoint, httpMethod string, acceptHeader string) (string, *Response, error) {
 	req, err := s.client.NewRequest(httpMethod, endpoint, nil)
 	if err != nil {
@@ -1,12 +1,13 @@
 package mailtrap
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
-	"time"
 )
 
 func TestMessagesService_Marshal(t *testing.T) {
@@ -238,6 +239,261 @@ func TestMessagesService_SpamReport(t *testing.T) {
 	})
 }
 
+func TestMessagesService_Headers(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/mail_headers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"headers": {
+				"date": "Thu, 01 Feb 2024 10:00:00 +0000",
+				"from": "=?UTF-8?Q?Caf=C3=A9?= <cafe@example.com>",
+				"to": ["john@example.com", "jane@example.com"],
+				"subject": "=?UTF-8?B?0J/RgNC40LLQtdGC?=",
+				"x-broken": "=?UNKNOWN?Q?abc?=",
+				"bcc": null
+			}
+		}`)
+	})
+
+	headers, _, err := client.Messages.Headers(1, 2, 3)
+	if err != nil {
+		t.Errorf("Messages.Headers returned error: %v", err)
+	}
+
+	expected := MessageHeaders{
+		"Date":     {"Thu, 01 Feb 2024 10:00:00 +0000"},
+		"From":     {"Café <cafe@example.com>"},
+		"To":       {"john@example.com", "jane@example.com"},
+		"Subject":  {"Привет"},
+		"X-Broken": {"=?UNKNOWN?Q?abc?="},
+	}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Errorf("Messages.Headers returned %+v, expected %+v", headers, expected)
+	}
+
+	if got := headers.Get("subject"); got != "Привет" {
+		t.Errorf("MessageHeaders.Get returned %q", got)
+	}
+	if got := headers.Values("TO"); len(got) != 2 {
+		t.Errorf("MessageHeaders.Values returned %q", got)
+	}
+	if got := headers.Get("cc"); got != "" {
+		t.Errorf("MessageHeaders.Get of missing header returned %q", got)
+	}
+
+	testBadPathParams(t, "Messages.Headers", func() error {
+		_, _, err = client.Messages.Headers(-1, -20, -30)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Messages.Headers", &client.client, func() (*Response, error) {
+		headers, resp, err := client.Messages.Headers(1, 2, 3)
+		if headers != nil {
+			t.Errorf("Messages.Headers client.BaseURL.Host=%v headers=%#v, want nil", client.baseURL.Host, headers)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_Headers_invalid(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/mail_headers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"headers":{"to":{"email":"john@example.com"}}}`)
+	})
+
+	_, _, err := client.Messages.Headers(1, 2, 3)
+	if err == nil {
+		t.Error("Messages.Headers with invalid value, err = nil, want error")
+	}
+}
+
+func TestMessagesService_HTMLAnalysis(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/analyze", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"report": {
+				"status": "success",
+				"errors": [
+					{
+						"error_line": 15,
+						"rule_name": "css-background-position",
+						"email_clients": {
+							"desktop": ["Outlook 2007-16"],
+							"mobile": [],
+							"web": ["Gmail"]
+						}
+					}
+				]
+			}
+		}`)
+	})
+
+	analysis, _, err := client.Messages.HTMLAnalysis(1, 2, 3)
+	if err != nil {
+		t.Errorf("Messages.HTMLAnalysis returned error: %v", err)
+	}
+
+	expected := &HTMLAnalysis{
+		Status: "success",
+		Errors: []*HTMLAnalysisError{{
+			ErrorLine: 15,
+			RuleName:  "css-background-position",
+			EmailClients: HTMLAnalysisClientList{
+				Desktop: []string{"Outlook 2007-16"},
+				Mobile:  []string{},
+				Web:     []string{"Gmail"},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(analysis, expected) {
+		t.Errorf("Messages.HTMLAnalysis returned %+v, expected %+v", analysis, expected)
+	}
+	if !analysis.HasErrors() {
+		t.Error("HTMLAnalysis.HasErrors returned false, want true")
+	}
+	if (&HTMLAnalysis{Status: "success"}).HasErrors() {
+		t.Error("HTMLAnalysis.HasErrors of clean report returned true")
+	}
+
+	testBadPathParams(t, "Messages.HTMLAnalysis", func() error {
+		_, _, err = client.Messages.HTMLAnalysis(-1, -20, -30)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Messages.HTMLAnalysis", &client.client, func() (*Response, error) {
+		analysis, resp, err := client.Messages.HTMLAnalysis(1, 2, 3)
+		if analysis != nil {
+			t.Errorf("Messages.HTMLAnalysis client.BaseURL.Host=%v analysis=%#v, want nil", client.baseURL.Host, analysis)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_BlacklistsReport(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/blacklists_report", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"report": {
+				"ip": "203.0.113.10",
+				"domain": "staging.example.com",
+				"blacklists": [
+					{"name": "Spamhaus ZEN", "url": "https://www.spamhaus.org", "in_black_list": false},
+					{"name": "Barracuda", "url": "https://www.barracudacentral.org", "in_black_list": true}
+				]
+			}
+		}`)
+	})
+
+	report, _, err := client.Messages.BlacklistsReport(1, 2, 3)
+	if err != nil {
+		t.Errorf("Messages.BlacklistsReport returned error: %v", err)
+	}
+
+	barracuda := &Blacklist{Name: "Barracuda", URL: "https://www.barracudacentral.org", InBlacklist: true}
+	expected := &BlacklistsReport{
+		IP:     "203.0.113.10",
+		Domain: "staging.example.com",
+		Blacklists: []*Blacklist{
+			{Name: "Spamhaus ZEN", URL: "https://www.spamhaus.org"},
+			barracuda,
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Messages.BlacklistsReport returned %+v, expected %+v", report, expected)
+	}
+
+	if listed := report.Listed(); !reflect.DeepEqual(listed, []*Blacklist{barracuda}) {
+		t.Errorf("BlacklistsReport.Listed returned %+v", listed)
+	}
+	if report.Clean() {
+		t.Error("BlacklistsReport.Clean returned true, want false")
+	}
+	if !(&BlacklistsReport{Blacklists: expected.Blacklists[:1]}).Clean() {
+		t.Error("BlacklistsReport.Clean of clean report returned false")
+	}
+
+	testBadPathParams(t, "Messages.BlacklistsReport", func() error {
+		_, _, err = client.Messages.BlacklistsReport(-1, -20, -30)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Messages.BlacklistsReport", &client.client, func() (*Response, error) {
+		report, resp, err := client.Messages.BlacklistsReport(1, 2, 3)
+		if report != nil {
+			t.Errorf("Messages.BlacklistsReport client.BaseURL.Host=%v report=%#v, want nil", client.baseURL.Host, report)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_Body(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	formats := map[BodyFormat]string{
+		BodyRaw:        "text/plain",
+		BodyText:       "text/plain",
+		BodyHTML:       "text/html",
+		BodyHTMLSource: "text/html",
+		BodyEML:        "message/rfc822",
+	}
+	for format, accept := range formats {
+		format, accept := format, accept
+		mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body."+string(format), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			testHeader(t, r, "Accept", accept)
+			fmt.Fprint(w, "body "+string(format))
+		})
+	}
+
+	for format := range formats {
+		body, _, err := client.Messages.Body(1, 2, 3, format)
+		if err != nil {
+			t.Errorf("Messages.Body(%s) returned error: %v", format, err)
+			continue
+		}
+		data, err := ioutil.ReadAll(body)
+		body.Close()
+		if err != nil || string(data) != "body "+string(format) {
+			t.Errorf("Messages.Body(%s) returned %q, %v", format, data, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, _, err := client.Messages.WriteBodyTo(&buf, 1, 2, 3, BodyEML)
+	if err != nil {
+		t.Errorf("Messages.WriteBodyTo returned error: %v", err)
+	}
+	if n != int64(buf.Len()) || buf.String() != "body eml" {
+		t.Errorf("Messages.WriteBodyTo wrote %d bytes %q", n, buf.String())
+	}
+
+	_, _, err = client.Messages.Body(1, 2, 3, "pdf")
+	if err == nil {
+		t.Error("Messages.Body with invalid format, err = nil, want error")
+	}
+
+	_, _, err = client.Messages.Body(1, 2, 4, BodyRaw)
+	if err == nil {
+		t.Error("Messages.Body of missing message, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "Messages.WriteBodyTo", &client.client, func() (*Response, error) {
+		_, resp, err := client.Messages.WriteBodyTo(&buf, 1, 2, 3, BodyRaw)
+		return resp, err
+	})
+}
+
 func TestMessagesService_AsRaw(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -461,7 +717,7 @@ func messageMock(ID int) *Message {
 	smtp.Data.MailFromAddr = "john@xample.com"
 	smtp.Data.ClientIP = "127.0.0.1"
 
-	datetime, _ := time.Parse(time.RFC3339, "2023-02-14T19:29:59.295Z")
+	datetime, _ := ParseTimestamp("2023-02-14T19:29:59.295Z")
 
 	return &Message{
 		ID:                   ID,
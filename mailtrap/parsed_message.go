@@ -0,0 +1,267 @@
+package mailtrap
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// ParsedMessage represents a message parsed into the MIME tree.
+type ParsedMessage struct {
+	// Header contains the message headers decoded from RFC 2047 encoded-words.
+	Header MessageHeaders
+	// Root is the top level part of the message.
+	Root *MIMEPart
+}
+
+// MIMEPart represents a part of the MIME tree.
+type MIMEPart struct {
+	// Header contains the raw part headers.
+	Header textproto.MIMEHeader
+	// ContentType is the lower-cased media type, e.g. "text/html".
+	ContentType string
+	// Params contains the content type parameters, e.g. charset or boundary.
+	Params           map[string]string
+	Charset          string
+	TransferEncoding string
+	// Disposition is the lower-cased content disposition, e.g. "inline" or "attachment".
+	Disposition string
+	Filename    string
+	// ContentID is the content ID without the angle brackets.
+	ContentID string
+	// Body is the content decoded from the transfer encoding. Empty for multipart parts.
+	Body []byte
+	// Parts contains the nested parts of multipart parts.
+	Parts []*MIMEPart
+
+	// related reports whether the part is nested in a multipart/related part.
+	related bool
+}
+
+// ParseMessage parses the message source into the MIME tree.
+func ParseMessage(r io.Reader) (*ParsedMessage, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parseMIMEPart(textproto.MIMEHeader(msg.Header), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := new(mime.WordDecoder)
+	header := make(MessageHeaders, len(msg.Header))
+	for k, values := range msg.Header {
+		for _, v := range values {
+			if decoded, err := dec.DecodeHeader(v); err == nil {
+				v = decoded
+			}
+			header[k] = append(header[k], v)
+		}
+	}
+
+	return &ParsedMessage{Header: header, Root: root}, nil
+}
+
+func parseMIMEPart(header textproto.MIMEHeader, body io.Reader) (*MIMEPart, error) {
+	p := &MIMEPart{
+		Header:           header,
+		TransferEncoding: strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))),
+		ContentID:        strings.Trim(strings.TrimSpace(header.Get("Content-Id")), "<>"),
+	}
+
+	// Messages without a valid content type are plain text, see RFC 2045.
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{"charset": "us-ascii"}
+	}
+	p.ContentType = mediaType
+	p.Params = params
+	p.Charset = strings.ToLower(params["charset"])
+
+	if cd := header.Get("Content-Disposition"); cd != "" {
+		disposition, dparams, err := mime.ParseMediaType(cd)
+		if err == nil {
+			p.Disposition = disposition
+			p.Filename = dparams["filename"]
+		}
+	}
+	if p.Filename == "" {
+		p.Filename = params["name"]
+	}
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(p.Filename); err == nil {
+		p.Filename = decoded
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return nil, fmt.Errorf("%s part has no boundary", mediaType)
+		}
+		mr := multipart.NewReader(body, boundary)
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			child, err := parseMIMEPart(part.Header, part)
+			if err != nil {
+				return nil, err
+			}
+			child.related = mediaType == "multipart/related"
+			p.Parts = append(p.Parts, child)
+		}
+		return p, nil
+	}
+
+	switch p.TransferEncoding {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	p.Body, err = ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s part: %w", mediaType, err)
+	}
+
+	return p, nil
+}
+
+// IsMultipart reports whether the part contains nested parts.
+func (p *MIMEPart) IsMultipart() bool {
+	return strings.HasPrefix(p.ContentType, "multipart/")
+}
+
+// IsAttachment reports whether the part is an attachment, i.e. it is not
+// an inline part and it has a file name or is marked as attachment.
+func (p *MIMEPart) IsAttachment() bool {
+	if p.IsMultipart() || p.IsInline() {
+		return false
+	}
+	return p.Disposition == "attachment" || p.Filename != ""
+}
+
+// IsInline reports whether the part is an inline file, e.g. an image referenced by the HTML body.
+// Besides the parts marked as inline, these are the parts with a content ID and no disposition
+// or nested in a multipart/related part, as "cid:" references usually point to.
+func (p *MIMEPart) IsInline() bool {
+	if p.IsMultipart() {
+		return false
+	}
+	if p.Disposition == "inline" {
+		return p.Filename != "" || p.ContentID != ""
+	}
+	return p.ContentID != "" && (p.Disposition == "" || p.related)
+}
+
+// Text returns the body converted to UTF-8. Only UTF-8, US-ASCII and
+// ISO-8859-1 charsets are supported.
+func (p *MIMEPart) Text() (string, error) {
+	switch p.Charset {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return string(p.Body), nil
+	case "iso-8859-1", "latin1":
+		runes := make([]rune, len(p.Body))
+		for i, b := range p.Body {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	}
+	return "", fmt.Errorf("unsupported charset %q", p.Charset)
+}
+
+// errSkipParts stops walking once the searched part is found.
+var errSkipParts = errors.New("skip parts")
+
+// Walk calls fn for the part and all its nested parts, depth first.
+// The depth of the root part is 0. Walking stops at the first error, which is returned.
+func (p *MIMEPart) Walk(fn func(part *MIMEPart, depth int) error) error {
+	return p.walk(fn, 0)
+}
+
+func (p *MIMEPart) walk(fn func(part *MIMEPart, depth int) error, depth int) error {
+	if err := fn(p, depth); err != nil {
+		return err
+	}
+	for _, child := range p.Parts {
+		if err := child.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for all parts of the message, depth first.
+func (m *ParsedMessage) Walk(fn func(part *MIMEPart, depth int) error) error {
+	return m.Root.Walk(fn)
+}
+
+// TextBody returns the first text/plain part which is not an attachment.
+func (m *ParsedMessage) TextBody() *MIMEPart {
+	return m.findBody("text/plain")
+}
+
+// HTMLBody returns the first text/html part which is not an attachment.
+func (m *ParsedMessage) HTMLBody() *MIMEPart {
+	return m.findBody("text/html")
+}
+
+func (m *ParsedMessage) findBody(contentType string) *MIMEPart {
+	var found *MIMEPart
+	m.Walk(func(p *MIMEPart, _ int) error {
+		if p.ContentType == contentType && !p.IsAttachment() {
+			found = p
+			return errSkipParts
+		}
+		return nil
+	})
+	return found
+}
+
+// Attachments returns the attachment parts.
+func (m *ParsedMessage) Attachments() []*MIMEPart {
+	return m.filter((*MIMEPart).IsAttachment)
+}
+
+// InlineParts returns the inline file parts, e.g. images embedded into the HTML body.
+func (m *ParsedMessage) InlineParts() []*MIMEPart {
+	return m.filter((*MIMEPart).IsInline)
+}
+
+func (m *ParsedMessage) filter(match func(*MIMEPart) bool) []*MIMEPart {
+	var parts []*MIMEPart
+	m.Walk(func(p *MIMEPart, _ int) error {
+		if match(p) {
+			parts = append(parts, p)
+		}
+		return nil
+	})
+	return parts
+}
+
+// PartByContentID returns the part with the content ID, as referenced by "cid:" URLs.
+func (m *ParsedMessage) PartByContentID(contentID string) *MIMEPart {
+	contentID = strings.Trim(strings.TrimPrefix(contentID, "cid:"), "<>")
+	var found *MIMEPart
+	m.Walk(func(p *MIMEPart, _ int) error {
+		if p.ContentID != "" && p.ContentID == contentID {
+			found = p
+			return errSkipParts
+		}
+		return nil
+	})
+	return found
+}
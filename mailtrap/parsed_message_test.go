@@ -0,0 +1,200 @@
+package mailtrap
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const rawMessageMock = "From: =?UTF-8?Q?Caf=C3=A9?= <cafe@example.com>\r\n" +
+	"To: john@example.com\r\n" +
+	"Subject: =?UTF-8?B?0J/RgNC40LLQtdGC?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"mixed\"\r\n" +
+	"\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: multipart/related; boundary=\"related\"\r\n" +
+	"\r\n" +
+	"--related\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"alt\"\r\n" +
+	"\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Caf=E9 au lait, a long line which is soft broken by the quoted-printable=\r\n" +
+	" encoding.\r\n" +
+	"--alt\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"PHA+SGVsbG8sIDxpbWcgc3JjPSJjaWQ6bG9nb0BleGFtcGxlIj48L3A+\r\n" +
+	"--alt--\r\n" +
+	"--related\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"Content-ID: <logo@example>\r\n" +
+	"Content-Disposition: inline; filename=\"logo.png\"\r\n" +
+	"\r\n" +
+	"iVBORw0K\r\n" +
+	"--related--\r\n" +
+	"--mixed\r\n" +
+	"Content-Type: application/pdf; name=\"=?UTF-8?Q?r=C3=A9sum=C3=A9.pdf?=\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"--mixed--\r\n"
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage(strings.NewReader(rawMessageMock))
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+
+	if got := msg.Header.Get("Subject"); got != "Привет" {
+		t.Errorf("ParsedMessage subject = %q", got)
+	}
+	if got := msg.Header.Get("From"); got != "Café <cafe@example.com>" {
+		t.Errorf("ParsedMessage from = %q", got)
+	}
+
+	var tree []string
+	msg.Walk(func(p *MIMEPart, depth int) error {
+		tree = append(tree, fmt.Sprintf("%s%s", strings.Repeat("  ", depth), p.ContentType))
+		return nil
+	})
+	want := []string{
+		"multipart/mixed",
+		"  multipart/related",
+		"    multipart/alternative",
+		"      text/plain",
+		"      text/html",
+		"    image/png",
+		"  application/pdf",
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("ParsedMessage tree = %q, want %q", tree, want)
+	}
+
+	text := msg.TextBody()
+	if text == nil || text.Charset != "iso-8859-1" || text.TransferEncoding != "quoted-printable" {
+		t.Fatalf("ParsedMessage.TextBody = %+v", text)
+	}
+	s, err := text.Text()
+	if err != nil {
+		t.Errorf("MIMEPart.Text returned error: %v", err)
+	}
+	if want := "Café au lait, a long line which is soft broken by the quoted-printable encoding."; s != want {
+		t.Errorf("MIMEPart.Text = %q, want %q", s, want)
+	}
+
+	html := msg.HTMLBody()
+	if html == nil || string(html.Body) != `<p>Hello, <img src="cid:logo@example"></p>` {
+		t.Errorf("ParsedMessage.HTMLBody = %+v", html)
+	}
+
+	inline := msg.InlineParts()
+	if len(inline) != 1 || inline[0].Filename != "logo.png" || inline[0].ContentID != "logo@example" {
+		t.Errorf("ParsedMessage.InlineParts = %+v", inline)
+	}
+	if got := msg.PartByContentID("cid:logo@example"); got != inline[0] {
+		t.Errorf("ParsedMessage.PartByContentID = %+v", got)
+	}
+	if string(inline[0].Body) != "\x89PNG\r\n" {
+		t.Errorf("inline part body = %q", inline[0].Body)
+	}
+
+	attachments := msg.Attachments()
+	if len(attachments) != 1 || attachments[0].Filename != "résumé.pdf" || string(attachments[0].Body) != "%PDF-1" {
+		t.Errorf("ParsedMessage.Attachments = %+v", attachments)
+	}
+}
+
+func TestParseMessage_relatedWithoutDisposition(t *testing.T) {
+	raw := "Content-Type: multipart/related; boundary=\"related\"\r\n" +
+		"\r\n" +
+		"--related\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<img src=\"cid:logo@example\">\r\n" +
+		"--related\r\n" +
+		"Content-Type: image/png; name=\"logo.png\"\r\n" +
+		"Content-ID: <logo@example>\r\n" +
+		"\r\n" +
+		"PNG\r\n" +
+		"--related--\r\n"
+	msg, err := ParseMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+
+	inline := msg.InlineParts()
+	if len(inline) != 1 || inline[0].Filename != "logo.png" || inline[0].ContentID != "logo@example" {
+		t.Errorf("ParsedMessage.InlineParts = %+v", inline)
+	}
+	if attachments := msg.Attachments(); len(attachments) != 0 {
+		t.Errorf("ParsedMessage.Attachments = %+v, want none", attachments)
+	}
+}
+
+func TestParseMessage_plain(t *testing.T) {
+	msg, err := ParseMessage(strings.NewReader("Subject: Hi\r\n\r\nHello"))
+	if err != nil {
+		t.Fatalf("ParseMessage returned error: %v", err)
+	}
+
+	if msg.Root.ContentType != "text/plain" || msg.Root.Charset != "us-ascii" {
+		t.Errorf("ParsedMessage root = %+v", msg.Root)
+	}
+	if body := msg.TextBody(); body == nil || string(body.Body) != "Hello" {
+		t.Errorf("ParsedMessage.TextBody = %+v", body)
+	}
+	if msg.HTMLBody() != nil || len(msg.Attachments()) != 0 {
+		t.Error("ParsedMessage of plain message has HTML body or attachments")
+	}
+}
+
+func TestParseMessage_invalid(t *testing.T) {
+	tests := map[string]string{
+		"no headers":  "",
+		"no boundary": "Content-Type: multipart/mixed\r\n\r\nbody",
+		"bad base64":  "Content-Type: text/plain\r\nContent-Transfer-Encoding: base64\r\n\r\n!!!",
+	}
+	for name, raw := range tests {
+		if _, err := ParseMessage(strings.NewReader(raw)); err == nil {
+			t.Errorf("ParseMessage with %s, err = nil, want error", name)
+		}
+	}
+
+	if _, err := (&MIMEPart{Charset: "koi8-r"}).Text(); err == nil {
+		t.Error("MIMEPart.Text with unsupported charset, err = nil, want error")
+	}
+}
+
+func TestMessagesService_Parsed(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.raw", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, rawMessageMock)
+	})
+
+	msg, _, err := client.Messages.Parsed(1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.Parsed returned error: %v", err)
+	}
+	if len(msg.Attachments()) != 1 {
+		t.Errorf("Messages.Parsed attachments = %+v", msg.Attachments())
+	}
+
+	testNewRequestAndDoFail(t, "Messages.Parsed", &client.client, func() (*Response, error) {
+		msg, resp, err := client.Messages.Parsed(1, 2, 3)
+		if msg != nil {
+			t.Errorf("Messages.Parsed client.BaseURL.Host=%v msg=%#v, want nil", client.baseURL.Host, msg)
+		}
+		return resp, err
+	})
+}
@@ -268,7 +268,7 @@ func projectMock(ID int) *Project {
 				EmailDomain:             "email-domain",
 				EmailsCount:             6,
 				EmailsUnreadCount:       7,
-				LastMessageSentAt:       "",
+				LastMessageSentAt:       Timestamp{},
 				SMTPPorts:               []int{25, 2525},
 				POP3Ports:               []int{1100, 9950},
 				MaxMessageSize:          100,
@@ -1,6 +1,7 @@
 package mailtrap
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -39,6 +40,31 @@ type SendEmailRequest struct {
 	// Required in the absence of text.
 	HTML     string `json:"html"`
 	Category string `json:"category"`
+
+	// UUID of the email template to send, see EmailTemplatesService.
+	// The subject, text, html and category are taken from the template and must not be set.
+	TemplateUUID string `json:"template_uuid,omitempty"`
+
+	// Values used to replace the template variables. Used only along with the template UUID.
+	TemplateVariables map[string]interface{} `json:"template_variables,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The subject, text, html
+// and category are omitted when the email is sent with a template, as the API
+// doesn't accept them along with the template UUID.
+func (r SendEmailRequest) MarshalJSON() ([]byte, error) {
+	type request SendEmailRequest
+	if r.TemplateUUID == "" {
+		return json.Marshal(request(r))
+	}
+
+	return json.Marshal(struct {
+		request
+		Subject  string `json:"subject,omitempty"`
+		Text     string `json:"text,omitempty"`
+		HTML     string `json:"html,omitempty"`
+		Category string `json:"category,omitempty"`
+	}{request: request(r)})
 }
 
 // EmailAddress represents an email address.
@@ -80,6 +106,27 @@ type SendEmailResponse struct {
 	MessageIDs []string `json:"message_ids"`
 }
 
+// SendFilter inspects the request before it is sent.
+//
+// The filter receives a shallow copy of the caller's request, so it may replace
+// the recipient slices, but must not modify their elements in place.
+// Returning an error aborts sending.
+type SendFilter func(request *SendEmailRequest) error
+
+// AddSendFilter registers a filter called before each Send, in order of registration.
+func (sc *SendingClient) AddSendFilter(filter SendFilter) {
+	sc.filters = append(sc.filters, filter)
+}
+
+// SendHook is called after the email has been sent successfully.
+// The request is the one actually sent, i.e. after the send filters were applied.
+type SendHook func(request *SendEmailRequest, response *SendEmailResponse)
+
+// AddSendHook registers a hook called after each successful Send, in order of registration.
+func (sc *SendingClient) AddSendHook(hook SendHook) {
+	sc.hooks = append(sc.hooks, hook)
+}
+
 // Send email
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/67f1d70aeb62c-send-email
@@ -88,6 +135,16 @@ func (sc *SendingClient) Send(request *SendEmailRequest) (*SendEmailResponse, *R
 		return nil, nil, errors.New("request `SendEmailRequest` is mandatory")
 	}
 
+	if len(sc.filters) > 0 {
+		filtered := *request
+		request = &filtered
+		for _, filter := range sc.filters {
+			if err := filter(request); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	if err := request.validate(); err != nil {
 		return nil, nil, err
 	}
@@ -103,6 +160,10 @@ func (sc *SendingClient) Send(request *SendEmailRequest) (*SendEmailResponse, *R
 		return nil, res, err
 	}
 
+	for _, hook := range sc.hooks {
+		hook(request, response)
+	}
+
 	return response, res, err
 }
 
@@ -136,6 +197,16 @@ func (r *SendEmailRequest) validate() error {
 		}
 	}
 
+	if r.TemplateUUID != "" {
+		if r.Subject != "" || r.Text != "" || r.HTML != "" || r.Category != "" {
+			return errors.New("'subject', 'text', 'html' and 'category' are not allowed with 'template_uuid'")
+		}
+		return nil
+	}
+	if len(r.TemplateVariables) > 0 {
+		return errors.New("'template_variables' require 'template_uuid'")
+	}
+
 	if r.Subject == "" {
 		return errors.New("'subject' is required")
 	}
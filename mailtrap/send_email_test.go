@@ -227,3 +227,38 @@ func emailRequestMock() *SendEmailRequest {
 		Category: "API Client",
 	}
 }
+
+func TestSendEmailService_Send_template(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"from":{"email":"test@example.com","name":""},"to":[{"email":"email@example.com","name":""}],"cc":null,"bcc":null,"attachments":null,"headers":null,"custom_variables":null,"template_uuid":"6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d","template_variables":{"user_name":"John"}}`)
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	email := &SendEmailRequest{
+		From:              EmailAddress{Email: "test@example.com"},
+		To:                []EmailAddress{{Email: "email@example.com"}},
+		TemplateUUID:      "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d",
+		TemplateVariables: map[string]interface{}{"user_name": "John"},
+	}
+	_, _, err := client.Send(email)
+	if err != nil {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+
+	email.Subject = "Subj."
+	_, _, err = client.Send(email)
+	if err == nil || err.Error() != "'subject', 'text', 'html' and 'category' are not allowed with 'template_uuid'" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+
+	email.Subject = ""
+	email.TemplateUUID = ""
+	_, _, err = client.Send(email)
+	if err == nil || err.Error() != "'template_variables' require 'template_uuid'" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+)
+
+type SendingDomainsServiceContract interface {
+	List(accountID int) ([]*SendingDomain, *Response, error)
+	Get(accountID, domainID int) (*SendingDomain, *Response, error)
+	Create(accountID int, domainName string) (*SendingDomain, *Response, error)
+	Delete(accountID, domainID int) (*Response, error)
+	SendSetupInstructions(accountID, domainID int, email string) (*Response, error)
+}
+
+type SendingDomainsService struct {
+	client *client
+}
+
+var _ SendingDomainsServiceContract = &SendingDomainsService{}
+
+// SendingDomain represents a Mailtrap sending domain.
+type SendingDomain struct {
+	ID         int    `json:"id"`
+	DomainName string `json:"domain_name"`
+	Demo       bool   `json:"demo"`
+	// compliance_status can return pending, approved, rejected
+	ComplianceStatus            string      `json:"compliance_status"`
+	DNSVerified                 bool        `json:"dns_verified"`
+	DNSVerifiedAt               Timestamp   `json:"dns_verified_at"`
+	DNSRecords                  []DNSRecord `json:"dns_records"`
+	OpenTrackingEnabled         bool        `json:"open_tracking_enabled"`
+	ClickTrackingEnabled        bool        `json:"click_tracking_enabled"`
+	AutoUnsubscribeLinkEnabled  bool        `json:"auto_unsubscribe_link_enabled"`
+	CustomDomainTrackingEnabled bool        `json:"custom_domain_tracking_enabled"`
+	HealthAlertsEnabled         bool        `json:"health_alerts_enabled"`
+	CriticalAlertsEnabled       bool        `json:"critical_alerts_enabled"`
+	AlertRecipientEmail         string      `json:"alert_recipient_email"`
+	Permissions                 Permissions `json:"permissions"`
+}
+
+// DNSRecord represents a DNS record required by the sending domain.
+type DNSRecord struct {
+	// key can return verification, spf, dkim1, dkim2, dmarc, link_tracking
+	Key    string `json:"key"`
+	Domain string `json:"domain"`
+	// type can return CNAME, TXT, MX
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	// status can return pass, fail, missing
+	Status string `json:"status"`
+	Name   string `json:"name"`
+}
+
+// Verified reports whether Mailtrap has verified the record.
+func (r DNSRecord) Verified() bool {
+	return r.Status == "pass"
+}
+
+type createSendingDomainRequest struct {
+	SendingDomain struct {
+		DomainName string `json:"domain_name"`
+	} `json:"sending_domain"`
+}
+
+// List returns the sending domains of the account.
+func (s *SendingDomainsService) List(accountID int) ([]*SendingDomain, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/sending_domains", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var domains []*SendingDomain
+	res, err := s.client.Do(req, &domains)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return domains, res, nil
+}
+
+// Get returns the sending domain with its DNS records and their verification status.
+func (s *SendingDomainsService) Get(accountID, domainID int) (*SendingDomain, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/sending_domains/%d", accountID, domainID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates a sending domain.
+func (s *SendingDomainsService) Create(accountID int, domainName string) (*SendingDomain, *Response, error) {
+	if domainName == "" {
+		return nil, nil, errors.New("sending domain 'domain_name' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/sending_domains", accountID)
+	payload := new(createSendingDomainRequest)
+	payload.SendingDomain.DomainName = domainName
+
+	return s.makeRequest(u, http.MethodPost, payload)
+}
+
+// Delete removes the sending domain.
+func (s *SendingDomainsService) Delete(accountID, domainID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/sending_domains/%d", accountID, domainID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+type setupInstructionsRequest struct {
+	Email string `json:"email"`
+}
+
+// SendSetupInstructions sends the domain DNS setup instructions to the email address.
+func (s *SendingDomainsService) SendSetupInstructions(accountID, domainID int, email string) (*Response, error) {
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, errors.New("setup instructions 'email' is invalid")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/sending_domains/%d/send_setup_instructions", accountID, domainID)
+	req, err := s.client.NewRequest(http.MethodPost, u, &setupInstructionsRequest{Email: email})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *SendingDomainsService) makeRequest(
+	endpoint, httpMethod string,
+	payload interface{},
+) (*SendingDomain, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var domain *SendingDomain
+	res, err := s.client.Do(req, &domain)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return domain, res, nil
+}
@@ -0,0 +1,172 @@
+package mailtrap
+
+import (
+	"net"
+	"strings"
+)
+
+// DNSResolver looks up the DNS records of a sending domain.
+type DNSResolver interface {
+	LookupTXT(name string) ([]string, error)
+	LookupCNAME(name string) (string, error)
+	LookupMX(name string) ([]*net.MX, error)
+}
+
+// NetResolver resolves DNS records using the system resolver.
+type NetResolver struct{}
+
+var _ DNSResolver = NetResolver{}
+
+// LookupTXT returns the DNS TXT records for the given domain name.
+func (NetResolver) LookupTXT(name string) ([]string, error) {
+	return net.LookupTXT(name)
+}
+
+// LookupCNAME returns the canonical name for the given host.
+func (NetResolver) LookupCNAME(name string) (string, error) {
+	return net.LookupCNAME(name)
+}
+
+// LookupMX returns the DNS MX records for the given domain name.
+func (NetResolver) LookupMX(name string) ([]*net.MX, error) {
+	return net.LookupMX(name)
+}
+
+// DNSCheckResult represents the result of a single DNS record check.
+type DNSCheckResult struct {
+	Record DNSRecord
+	// Found contains the values published in DNS.
+	Found []string
+	OK    bool
+	// Err is the lookup error, if any.
+	Err error
+}
+
+// DomainDNSReport represents the result of the sending domain DNS check.
+type DomainDNSReport struct {
+	Domain  string
+	Results []DNSCheckResult
+}
+
+// OK reports whether all the records are published correctly.
+func (r *DomainDNSReport) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks of the missing or misconfigured records.
+func (r *DomainDNSReport) Failed() []DNSCheckResult {
+	var failed []DNSCheckResult
+	for _, res := range r.Results {
+		if !res.OK {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// CheckDomainDNS checks that the DNS records required by the sending domain,
+// i.e. domain verification, SPF, DKIM and DMARC, are published.
+//
+// SPF records pass when they include all the mechanisms required by Mailtrap,
+// DMARC records pass when any DMARC policy is published, the rest must match exactly.
+// If resolver is nil, NetResolver is used.
+func CheckDomainDNS(domain *SendingDomain, resolver DNSResolver) *DomainDNSReport {
+	if resolver == nil {
+		resolver = NetResolver{}
+	}
+
+	report := &DomainDNSReport{Domain: domain.DomainName}
+	for _, record := range domain.DNSRecords {
+		report.Results = append(report.Results, checkDNSRecord(domain.DomainName, record, resolver))
+	}
+	return report
+}
+
+func checkDNSRecord(domainName string, record DNSRecord, resolver DNSResolver) DNSCheckResult {
+	result := DNSCheckResult{Record: record}
+
+	host := record.Domain
+	if host == "" {
+		host = domainName
+		if record.Name != "" && record.Name != "@" {
+			host = record.Name + "." + domainName
+		}
+	}
+
+	switch strings.ToUpper(record.Type) {
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(host)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Found = []string{cname}
+		result.OK = equalHosts(cname, record.Value)
+	case "MX":
+		mx, err := resolver.LookupMX(host)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		for _, m := range mx {
+			result.Found = append(result.Found, m.Host)
+			if equalHosts(m.Host, record.Value) {
+				result.OK = true
+			}
+		}
+	case "TXT":
+		txt, err := resolver.LookupTXT(host)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Found = txt
+		for _, v := range txt {
+			if matchTXTRecord(record, v) {
+				result.OK = true
+			}
+		}
+	}
+
+	return result
+}
+
+func matchTXTRecord(record DNSRecord, found string) bool {
+	want := strings.TrimSpace(record.Value)
+	found = strings.TrimSpace(found)
+
+	switch {
+	case record.Key == "spf" || hasPrefixFold(want, "v=spf1"):
+		if !hasPrefixFold(found, "v=spf1") {
+			return false
+		}
+		mechanisms := strings.Fields(strings.ToLower(found))
+		for _, m := range strings.Fields(strings.ToLower(want)) {
+			if strings.HasPrefix(m, "include:") && !containsString(mechanisms, m) {
+				return false
+			}
+		}
+		return true
+	case record.Key == "dmarc" || hasPrefixFold(want, "v=DMARC1"):
+		return hasPrefixFold(found, "v=DMARC1")
+	default:
+		return found == want
+	}
+}
+
+func equalHosts(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package mailtrap
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// fakeResolver resolves DNS records from memory.
+type fakeResolver struct {
+	txt   map[string][]string
+	cname map[string]string
+	mx    map[string][]*net.MX
+}
+
+func (r *fakeResolver) LookupTXT(name string) ([]string, error) {
+	if v, ok := r.txt[name]; ok {
+		return v, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupCNAME(name string) (string, error) {
+	if v, ok := r.cname[name]; ok {
+		return v, nil
+	}
+	return "", &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupMX(name string) ([]*net.MX, error) {
+	if v, ok := r.mx[name]; ok {
+		return v, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func dnsCheckDomainMock() *SendingDomain {
+	return &SendingDomain{
+		DomainName: "example.com",
+		DNSRecords: []DNSRecord{
+			{Key: "verification", Domain: "ve6wza2rbpe60x7z.example.com", Type: "CNAME", Value: "smtp.mailtrap.live"},
+			{Key: "spf", Domain: "example.com", Type: "TXT", Value: "v=spf1 include:_spf.smtp.mailtrap.live ~all"},
+			{Key: "dkim1", Name: "rwmt1._domainkey", Type: "CNAME", Value: "rwmt1.dkim.smtp.mailtrap.live"},
+			{Key: "dmarc", Domain: "_dmarc.example.com", Type: "TXT", Value: "v=DMARC1; p=none; rua=mailto:dmarc@smtp.mailtrap.live"},
+			{Key: "mx", Domain: "example.com", Type: "MX", Value: "mx.example.com"},
+		},
+	}
+}
+
+func TestCheckDomainDNS(t *testing.T) {
+	resolver := &fakeResolver{
+		txt: map[string][]string{
+			"example.com":        {"google-site-verification=abc", "v=spf1 include:_spf.google.com include:_spf.smtp.mailtrap.live -all"},
+			"_dmarc.example.com": {"v=DMARC1; p=reject"},
+		},
+		cname: map[string]string{
+			"ve6wza2rbpe60x7z.example.com": "smtp.mailtrap.live.",
+			"rwmt1._domainkey.example.com": "RWMT1.dkim.smtp.mailtrap.live.",
+		},
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.com.", Pref: 10}},
+		},
+	}
+
+	report := CheckDomainDNS(dnsCheckDomainMock(), resolver)
+	if !report.OK() {
+		t.Errorf("CheckDomainDNS failed records: %+v", report.Failed())
+	}
+	if len(report.Results) != 5 {
+		t.Errorf("CheckDomainDNS returned %d results, want 5", len(report.Results))
+	}
+	if want := []string{"smtp.mailtrap.live."}; !reflect.DeepEqual(report.Results[0].Found, want) {
+		t.Errorf("CheckDomainDNS found %v, want %v", report.Results[0].Found, want)
+	}
+}
+
+func TestCheckDomainDNS_failed(t *testing.T) {
+	resolver := &fakeResolver{
+		txt: map[string][]string{
+			"example.com":        {"v=spf1 include:_spf.google.com -all"},
+			"_dmarc.example.com": {"not a dmarc record"},
+		},
+		cname: map[string]string{
+			"ve6wza2rbpe60x7z.example.com": "example.net.",
+		},
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.net.", Pref: 10}},
+		},
+	}
+
+	report := CheckDomainDNS(dnsCheckDomainMock(), resolver)
+	if report.OK() {
+		t.Error("CheckDomainDNS OK = true, want false")
+	}
+
+	failed := report.Failed()
+	if len(failed) != 5 {
+		t.Fatalf("CheckDomainDNS returned %d failed records, want 5", len(failed))
+	}
+
+	var dnsErr *net.DNSError
+	if !errors.As(failed[2].Err, &dnsErr) {
+		t.Errorf("CheckDomainDNS missing record error is %v, want DNS error", failed[2].Err)
+	}
+	for _, r := range failed {
+		if r.Record.Key != "dkim1" && r.Err != nil {
+			t.Errorf("CheckDomainDNS %s record error is %v, want nil", r.Record.Key, r.Err)
+		}
+	}
+}
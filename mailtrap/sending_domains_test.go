@@ -0,0 +1,233 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSendingDomainsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &SendingDomain{}, "{}")
+
+	u := sendingDomainMock(1)
+	want := `{
+		"id": 1,
+		"domain_name": "example.com",
+		"demo": false,
+		"compliance_status": "approved",
+		"dns_verified": true,
+		"dns_verified_at": "2023-02-14T19:29:59.295Z",
+		"dns_records": [
+			{
+				"key": "verification",
+				"domain": "ve6wza2rbpe60x7z.example.com",
+				"type": "CNAME",
+				"value": "smtp.mailtrap.live",
+				"status": "pass",
+				"name": "ve6wza2rbpe60x7z"
+			},
+			{
+				"key": "spf",
+				"domain": "example.com",
+				"type": "TXT",
+				"value": "v=spf1 include:_spf.smtp.mailtrap.live ~all",
+				"status": "pass",
+				"name": ""
+			}
+		],
+		"open_tracking_enabled": true,
+		"click_tracking_enabled": true,
+		"auto_unsubscribe_link_enabled": true,
+		"custom_domain_tracking_enabled": false,
+		"health_alerts_enabled": true,
+		"critical_alerts_enabled": true,
+		"alert_recipient_email": "john@example.com",
+		"permissions": {
+			"can_read": true,
+			"can_update": true,
+			"can_destroy": true,
+			"can_leave": false
+		}
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestSendingDomainsService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedDomains := []*SendingDomain{sendingDomainMock(1), sendingDomainMock(2)}
+
+	mux.HandleFunc("/accounts/1/sending_domains", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedDomains)
+		fmt.Fprint(w, string(resp))
+	})
+
+	domains, _, err := client.SendingDomains.List(1)
+	if err != nil {
+		t.Errorf("SendingDomains.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(domains, expectedDomains) {
+		t.Errorf("SendingDomains.List returned %+v, expected %+v", domains, expectedDomains)
+	}
+
+	testBadPathParams(t, "SendingDomains.List", func() error {
+		_, _, err = client.SendingDomains.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "SendingDomains.List", &client.client, func() (*Response, error) {
+		domains, resp, err := client.SendingDomains.List(1)
+		if domains != nil {
+			t.Errorf("SendingDomains.List client.BaseURL.Host=%v domains=%#v, want nil", client.baseURL.Host, domains)
+		}
+		return resp, err
+	})
+}
+
+func TestSendingDomainsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedDomain := sendingDomainMock(2)
+
+	mux.HandleFunc("/accounts/1/sending_domains/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedDomain)
+		fmt.Fprint(w, string(resp))
+	})
+
+	domain, _, err := client.SendingDomains.Get(1, 2)
+	if err != nil {
+		t.Errorf("SendingDomains.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(domain, expectedDomain) {
+		t.Errorf("SendingDomains.Get returned %+v, expected %+v", domain, expectedDomain)
+	}
+	if !domain.DNSRecords[0].Verified() {
+		t.Error("DNSRecord.Verified = false, want true")
+	}
+
+	testBadPathParams(t, "SendingDomains.Get", func() error {
+		_, _, err = client.SendingDomains.Get(-1, -2)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "SendingDomains.Get", &client.client, func() (*Response, error) {
+		domain, resp, err := client.SendingDomains.Get(1, 2)
+		if domain != nil {
+			t.Errorf("SendingDomains.Get client.BaseURL.Host=%v domain=%#v, want nil", client.baseURL.Host, domain)
+		}
+		return resp, err
+	})
+}
+
+func TestSendingDomainsService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/sending_domains", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"sending_domain":{"domain_name":"example.com"}}`)
+		fmt.Fprint(w, `{"id":1,"domain_name":"example.com","dns_verified":false}`)
+	})
+
+	domain, _, err := client.SendingDomains.Create(1, "example.com")
+	if err != nil {
+		t.Errorf("SendingDomains.Create returned error: %v", err)
+	}
+
+	expected := &SendingDomain{ID: 1, DomainName: "example.com"}
+	if !reflect.DeepEqual(domain, expected) {
+		t.Errorf("SendingDomains.Create returned %+v, expected %+v", domain, expected)
+	}
+
+	_, _, err = client.SendingDomains.Create(1, "")
+	if err == nil {
+		t.Error("SendingDomains.Create without domain name, err = nil, want error")
+	}
+}
+
+func TestSendingDomainsService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/sending_domains/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.SendingDomains.Delete(1, 2)
+	if err != nil {
+		t.Errorf("SendingDomains.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "SendingDomains.Delete", &client.client, func() (*Response, error) {
+		return client.SendingDomains.Delete(1, 2)
+	})
+}
+
+func TestSendingDomainsService_SendSetupInstructions(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/sending_domains/2/send_setup_instructions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"email":"devops@example.com"}`)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.SendingDomains.SendSetupInstructions(1, 2, "devops@example.com")
+	if err != nil {
+		t.Errorf("SendingDomains.SendSetupInstructions returned error: %v", err)
+	}
+
+	_, err = client.SendingDomains.SendSetupInstructions(1, 2, "devops")
+	if err == nil {
+		t.Error("SendingDomains.SendSetupInstructions invalid email, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "SendingDomains.SendSetupInstructions", &client.client, func() (*Response, error) {
+		return client.SendingDomains.SendSetupInstructions(1, 2, "devops@example.com")
+	})
+}
+
+func sendingDomainMock(ID int) *SendingDomain {
+	datetime, _ := ParseTimestamp("2023-02-14T19:29:59.295Z")
+
+	return &SendingDomain{
+		ID:               ID,
+		DomainName:       "example.com",
+		ComplianceStatus: "approved",
+		DNSVerified:      true,
+		DNSVerifiedAt:    datetime,
+		DNSRecords: []DNSRecord{
+			{
+				Key:    "verification",
+				Domain: "ve6wza2rbpe60x7z.example.com",
+				Type:   "CNAME",
+				Value:  "smtp.mailtrap.live",
+				Status: "pass",
+				Name:   "ve6wza2rbpe60x7z",
+			},
+			{
+				Key:    "spf",
+				Domain: "example.com",
+				Type:   "TXT",
+				Value:  "v=spf1 include:_spf.smtp.mailtrap.live ~all",
+				Status: "pass",
+			},
+		},
+		OpenTrackingEnabled:        true,
+		ClickTrackingEnabled:       true,
+		AutoUnsubscribeLinkEnabled: true,
+		HealthAlertsEnabled:        true,
+		CriticalAlertsEnabled:      true,
+		AlertRecipientEmail:        "john@example.com",
+		Permissions:                Permissions{CanRead: true, CanUpdate: true, CanDestroy: true},
+	}
+}
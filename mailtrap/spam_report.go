@@ -0,0 +1,125 @@
+package mailtrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// SpamRule represents a SpamAssassin rule fired for the message.
+type SpamRule struct {
+	RuleName    string     `json:"RuleName"`
+	Pts         SpamPoints `json:"Pts"`
+	Description string     `json:"Description"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Besides objects, the API may return the rules as bare strings or null.
+func (r *SpamRule) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*r = SpamRule{RuleName: s, Description: s}
+		return nil
+	}
+
+	type rule SpamRule
+	return json.Unmarshal(data, (*rule)(r))
+}
+
+// SpamPoints represents the score points of a spam rule.
+// The API returns the points either as a number or a string.
+type SpamPoints float64
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *SpamPoints) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		*p = 0
+	case float64:
+		*p = SpamPoints(v)
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			*p = 0
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid spam rule points %q", v)
+		}
+		*p = SpamPoints(f)
+	default:
+		return fmt.Errorf("invalid spam rule points %s", data)
+	}
+
+	return nil
+}
+
+// FiredRules returns the rules affecting the score, i.e. with non-zero points,
+// ordered by points descending.
+func (r *SpamReport) FiredRules() []*SpamRule {
+	var fired []*SpamRule
+	for _, rule := range r.Report.Details {
+		if rule != nil && rule.Pts != 0 {
+			fired = append(fired, rule)
+		}
+	}
+	sort.SliceStable(fired, func(i, j int) bool { return fired[i].Pts > fired[j].Pts })
+	return fired
+}
+
+// Exceeds reports whether the spam score is above the threshold.
+func (r *SpamReport) Exceeds(threshold float64) bool {
+	return r.Report.Score > threshold
+}
+
+// RulesTable returns the fired rules formatted as a table.
+func (r *SpamReport) RulesTable() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POINTS\tRULE\tDESCRIPTION")
+	for _, rule := range r.FiredRules() {
+		fmt.Fprintf(w, "%.1f\t%s\t%s\n", float64(rule.Pts), rule.RuleName, rule.Description)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// TestingT is the subset of testing.TB used by the assertion helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertSpamScore reports a test error listing the fired rules if the spam score
+// of the report is above the budget. It returns whether the score is within the budget.
+func AssertSpamScore(t TestingT, report *SpamReport, budget float64) bool {
+	t.Helper()
+
+	if report == nil {
+		t.Errorf("spam report is nil")
+		return false
+	}
+	if report.Exceeds(budget) {
+		t.Errorf("spam score %.1f exceeds the budget %.1f:\n%s", report.Report.Score, budget, report.RulesTable())
+		return false
+	}
+
+	return true
+}
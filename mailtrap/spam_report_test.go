@@ -0,0 +1,130 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+const spamReportJSON = `{
+	"report": {
+		"ResponseCode": 2,
+		"ResponseMessage": "Not spam",
+		"ResponseVersion": "1.1",
+		"Score": 3.4,
+		"Spam": false,
+		"Threshold": 5,
+		"Details": [
+			{"Pts": "0.0", "RuleName": "HTML_MESSAGE", "Description": "BODY: HTML included in message"},
+			{"Pts": -0.1, "RuleName": "DKIM_VALID", "Description": "Message has at least one valid DKIM signature"},
+			{"Pts": "2.5", "RuleName": "MIME_HTML_ONLY", "Description": "BODY: Message only has text/html MIME parts"},
+			{"Pts": 1, "RuleName": "MISSING_MID", "Description": "Missing Message-Id: header"},
+			{"Pts": null, "RuleName": "EMPTY", "Description": ""}
+		]
+	}
+}`
+
+func TestSpamReport_Unmarshal(t *testing.T) {
+	var report SpamReport
+	if err := json.Unmarshal([]byte(spamReportJSON), &report); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	var points []SpamPoints
+	for _, rule := range report.Report.Details {
+		points = append(points, rule.Pts)
+	}
+	if want := []SpamPoints{0, -0.1, 2.5, 1, 0}; !reflect.DeepEqual(points, want) {
+		t.Errorf("SpamReport points = %v, want %v", points, want)
+	}
+
+	var legacy SpamReport
+	if err := json.Unmarshal([]byte(`{"report":{"Score":1.2,"Details":["some rule",null]}}`), &legacy); err != nil {
+		t.Fatalf("json.Unmarshal with string details returned error: %v", err)
+	}
+	details := legacy.Report.Details
+	if len(details) != 2 || details[0].RuleName != "some rule" || details[0].Description != "some rule" || details[1] != nil {
+		t.Errorf("SpamReport string details = %+v", details)
+	}
+	if fired := legacy.FiredRules(); len(fired) != 0 {
+		t.Errorf("SpamReport.FiredRules with string details = %+v, want none", fired)
+	}
+
+	var rule SpamRule
+	if err := json.Unmarshal([]byte(`null`), &rule); err != nil || rule != (SpamRule{}) {
+		t.Errorf("json.Unmarshal(null) = %+v, %v", rule, err)
+	}
+
+	for _, data := range []string{`{"Pts":"high"}`, `{"Pts":true}`} {
+		var rule SpamRule
+		if err := json.Unmarshal([]byte(data), &rule); err == nil {
+			t.Errorf("json.Unmarshal(%s), err = nil, want error", data)
+		}
+	}
+}
+
+func TestSpamReport_FiredRules(t *testing.T) {
+	var report SpamReport
+	if err := json.Unmarshal([]byte(spamReportJSON), &report); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	var names []string
+	for _, rule := range report.FiredRules() {
+		names = append(names, rule.RuleName)
+	}
+	if want := []string{"MIME_HTML_ONLY", "MISSING_MID", "DKIM_VALID"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("SpamReport.FiredRules = %v, want %v", names, want)
+	}
+
+	if !report.Exceeds(3) {
+		t.Error("SpamReport.Exceeds(3) = false, want true")
+	}
+	if report.Exceeds(3.4) {
+		t.Error("SpamReport.Exceeds(3.4) = true, want false")
+	}
+
+	want := "POINTS  RULE            DESCRIPTION\n" +
+		"2.5     MIME_HTML_ONLY  BODY: Message only has text/html MIME parts\n" +
+		"1.0     MISSING_MID     Missing Message-Id: header\n" +
+		"-0.1    DKIM_VALID      Message has at least one valid DKIM signature\n"
+	if got := report.RulesTable(); got != want {
+		t.Errorf("SpamReport.RulesTable =\n%s\nwant\n%s", got, want)
+	}
+}
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSpamScore(t *testing.T) {
+	var report SpamReport
+	if err := json.Unmarshal([]byte(spamReportJSON), &report); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	ft := &fakeT{}
+	if !AssertSpamScore(ft, &report, 5) || len(ft.errors) != 0 {
+		t.Errorf("AssertSpamScore within budget reported %v", ft.errors)
+	}
+
+	if AssertSpamScore(ft, &report, 2) || len(ft.errors) != 1 {
+		t.Fatalf("AssertSpamScore above budget reported %v", ft.errors)
+	}
+	want := "spam score 3.4 exceeds the budget 2.0:\n" + report.RulesTable()
+	if ft.errors[0] != want {
+		t.Errorf("AssertSpamScore reported %q, want %q", ft.errors[0], want)
+	}
+
+	ft = &fakeT{}
+	if AssertSpamScore(ft, nil, 5) || len(ft.errors) != 1 {
+		t.Errorf("AssertSpamScore with nil report reported %v", ft.errors)
+	}
+}
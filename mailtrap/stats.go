@@ -0,0 +1,237 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type StatsServiceContract interface {
+	Get(accountID int, params *StatsParams) (*SendingStats, *Response, error)
+	ByDate(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error)
+	ByCategory(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error)
+	ByDomain(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error)
+	ByEmailServiceProvider(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error)
+}
+
+type StatsService struct {
+	client *client
+}
+
+var _ StatsServiceContract = &StatsService{}
+
+// statsDateLayout is the format of the stats dates.
+const statsDateLayout = "2006-01-02"
+
+// SendingStats represents the sending statistics.
+//
+// Delivery and bounce rates are fractions of the sent emails, i.e. delivered
+// and bounced ones. Open, click and spam rates are fractions of the delivered emails.
+type SendingStats struct {
+	DeliveryCount int     `json:"delivery_count"`
+	DeliveryRate  float64 `json:"delivery_rate"`
+	BounceCount   int     `json:"bounce_count"`
+	BounceRate    float64 `json:"bounce_rate"`
+	OpenCount     int     `json:"open_count"`
+	OpenRate      float64 `json:"open_rate"`
+	ClickCount    int     `json:"click_count"`
+	ClickRate     float64 `json:"click_rate"`
+	SpamCount     int     `json:"spam_count"`
+	SpamRate      float64 `json:"spam_rate"`
+}
+
+// SendingStatsGroup represents the sending statistics of a group.
+// Only the key of the requested grouping is set.
+type SendingStatsGroup struct {
+	Date                 string       `json:"date,omitempty"`
+	Category             string       `json:"category,omitempty"`
+	SendingDomainID      int          `json:"sending_domain_id,omitempty"`
+	EmailServiceProvider string       `json:"email_service_provider,omitempty"`
+	Stats                SendingStats `json:"stats"`
+}
+
+// StatsParams represents the available stats query parameters.
+type StatsParams struct {
+	// StartDate and EndDate are the days of the period, both inclusive. Required.
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Optional filters.
+	SendingDomainIDs      []int
+	SendingStreams        []string
+	Categories            []string
+	EmailServiceProviders []string
+}
+
+func (p *StatsParams) validate() error {
+	if p == nil || p.StartDate.IsZero() || p.EndDate.IsZero() {
+		return errors.New("stats 'start_date' and 'end_date' are required")
+	}
+	if p.EndDate.Before(p.StartDate) {
+		return errors.New("stats 'end_date' is before 'start_date'")
+	}
+	return nil
+}
+
+func (p *StatsParams) values() url.Values {
+	v := url.Values{}
+	v.Set("start_date", p.StartDate.Format(statsDateLayout))
+	v.Set("end_date", p.EndDate.Format(statsDateLayout))
+	for _, id := range p.SendingDomainIDs {
+		v.Add("sending_domain_ids[]", strconv.Itoa(id))
+	}
+	for _, s := range p.SendingStreams {
+		v.Add("sending_streams[]", s)
+	}
+	for _, c := range p.Categories {
+		v.Add("categories[]", c)
+	}
+	for _, esp := range p.EmailServiceProviders {
+		v.Add("email_service_providers[]", esp)
+	}
+	return v
+}
+
+// Get returns the sending statistics of the period.
+func (s *StatsService) Get(accountID int, params *StatsParams) (*SendingStats, *Response, error) {
+	var stats *SendingStats
+	res, err := s.makeRequest(fmt.Sprintf("/accounts/%d/stats", accountID), params, &stats)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return stats, res, nil
+}
+
+// ByDate returns the sending statistics of the period grouped by day.
+func (s *StatsService) ByDate(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error) {
+	return s.group(accountID, "date", params)
+}
+
+// ByCategory returns the sending statistics of the period grouped by category.
+func (s *StatsService) ByCategory(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error) {
+	return s.group(accountID, "categories", params)
+}
+
+// ByDomain returns the sending statistics of the period grouped by sending domain.
+func (s *StatsService) ByDomain(accountID int, params *StatsParams) ([]*SendingStatsGroup, *Response, error) {
+	return s.group(accountID, "domains", params)
+}
+
+// ByEmailServiceProvider returns the sending statistics of the period grouped by
+// the mailbox provider of the recipients, e.g. Google or Yahoo.
+func (s *StatsService) ByEmailServiceProvider(
+	accountID int,
+	params *StatsParams,
+) ([]*SendingStatsGroup, *Response, error) {
+	return s.group(accountID, "email_service_providers", params)
+}
+
+func (s *StatsService) group(accountID int, by string, params *StatsParams) ([]*SendingStatsGroup, *Response, error) {
+	var groups []*SendingStatsGroup
+	res, err := s.makeRequest(fmt.Sprintf("/accounts/%d/stats/%s", accountID, by), params, &groups)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return groups, res, nil
+}
+
+func (s *StatsService) makeRequest(endpoint string, params *StatsParams, v interface{}) (*Response, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = params.values().Encode()
+
+	return s.client.Do(req, v)
+}
+
+// Sent returns the number of sent emails, i.e. delivered and bounced ones.
+func (s SendingStats) Sent() int {
+	return s.DeliveryCount + s.BounceCount
+}
+
+// ComputeRates sets the rates from the counts.
+func (s *SendingStats) ComputeRates() {
+	s.DeliveryRate = rate(s.DeliveryCount, s.Sent())
+	s.BounceRate = rate(s.BounceCount, s.Sent())
+	s.OpenRate = rate(s.OpenCount, s.DeliveryCount)
+	s.ClickRate = rate(s.ClickCount, s.DeliveryCount)
+	s.SpamRate = rate(s.SpamCount, s.DeliveryCount)
+}
+
+func rate(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+// MergeStats sums the counts of the stats and computes the rates of the total.
+func MergeStats(stats ...SendingStats) SendingStats {
+	var total SendingStats
+	for _, s := range stats {
+		total.DeliveryCount += s.DeliveryCount
+		total.BounceCount += s.BounceCount
+		total.OpenCount += s.OpenCount
+		total.ClickCount += s.ClickCount
+		total.SpamCount += s.SpamCount
+	}
+	total.ComputeRates()
+	return total
+}
+
+// TotalStats merges the stats of the groups.
+func TotalStats(groups []*SendingStatsGroup) SendingStats {
+	stats := make([]SendingStats, len(groups))
+	for i, g := range groups {
+		stats[i] = g.Stats
+	}
+	return MergeStats(stats...)
+}
+
+// StatsComparison represents the change of the stats between two periods.
+type StatsComparison struct {
+	Previous SendingStats
+	Current  SendingStats
+	// Delta holds the differences of the counts and rates, current minus previous.
+	Delta SendingStats
+}
+
+// CompareStats compares the stats of the current period with the previous one.
+func CompareStats(previous, current SendingStats) *StatsComparison {
+	return &StatsComparison{
+		Previous: previous,
+		Current:  current,
+		Delta: SendingStats{
+			DeliveryCount: current.DeliveryCount - previous.DeliveryCount,
+			DeliveryRate:  current.DeliveryRate - previous.DeliveryRate,
+			BounceCount:   current.BounceCount - previous.BounceCount,
+			BounceRate:    current.BounceRate - previous.BounceRate,
+			OpenCount:     current.OpenCount - previous.OpenCount,
+			OpenRate:      current.OpenRate - previous.OpenRate,
+			ClickCount:    current.ClickCount - previous.ClickCount,
+			ClickRate:     current.ClickRate - previous.ClickRate,
+			SpamCount:     current.SpamCount - previous.SpamCount,
+			SpamRate:      current.SpamRate - previous.SpamRate,
+		},
+	}
+}
+
+// PreviousPeriod returns the params of the period of the same length right before
+// the params period, keeping the filters. Useful to compare with CompareStats.
+func (p *StatsParams) PreviousPeriod() *StatsParams {
+	days := int(p.EndDate.Sub(p.StartDate).Hours()/24+0.5) + 1
+	prev := *p
+	prev.EndDate = p.StartDate.AddDate(0, 0, -1)
+	prev.StartDate = p.StartDate.AddDate(0, 0, -days)
+	return &prev
+}
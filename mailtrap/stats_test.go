@@ -0,0 +1,221 @@
+package mailtrap
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const sendingStatsJSON = `{
+	"delivery_count": 90,
+	"delivery_rate": 0.9,
+	"bounce_count": 10,
+	"bounce_rate": 0.1,
+	"open_count": 45,
+	"open_rate": 0.5,
+	"click_count": 9,
+	"click_rate": 0.1,
+	"spam_count": 0,
+	"spam_rate": 0
+}`
+
+func statsParamsMock() *StatsParams {
+	return &StatsParams{
+		StartDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestStatsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, sendingStatsMock(), sendingStatsJSON)
+	testJSONMarshal(t, &SendingStatsGroup{Category: "Orders", Stats: *sendingStatsMock()}, `{"category":"Orders","stats":`+sendingStatsJSON+`}`)
+}
+
+func TestStatsService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/stats", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		want := url.Values{
+			"start_date":                {"2024-03-01"},
+			"end_date":                  {"2024-03-07"},
+			"sending_domain_ids[]":      {"1", "2"},
+			"sending_streams[]":         {"transactional"},
+			"categories[]":              {"Orders"},
+			"email_service_providers[]": {"Google"},
+		}
+		if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Request query is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, sendingStatsJSON)
+	})
+
+	params := statsParamsMock()
+	params.SendingDomainIDs = []int{1, 2}
+	params.SendingStreams = []string{"transactional"}
+	params.Categories = []string{"Orders"}
+	params.EmailServiceProviders = []string{"Google"}
+
+	stats, _, err := client.Stats.Get(1, params)
+	if err != nil {
+		t.Errorf("Stats.Get returned error: %v", err)
+	}
+
+	if expected := sendingStatsMock(); !reflect.DeepEqual(stats, expected) {
+		t.Errorf("Stats.Get returned %+v, expected %+v", stats, expected)
+	}
+
+	_, _, err = client.Stats.Get(1, nil)
+	if err == nil {
+		t.Error("Stats.Get without period, err = nil, want error")
+	}
+
+	_, _, err = client.Stats.Get(1, &StatsParams{StartDate: params.EndDate, EndDate: params.StartDate})
+	if err == nil {
+		t.Error("Stats.Get with reversed period, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "Stats.Get", &client.client, func() (*Response, error) {
+		stats, resp, err := client.Stats.Get(1, params)
+		if stats != nil {
+			t.Errorf("Stats.Get client.BaseURL.Host=%v stats=%#v, want nil", client.baseURL.Host, stats)
+		}
+		return resp, err
+	})
+}
+
+func TestStatsService_Groups(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	tests := []struct {
+		name     string
+		path     string
+		key      string
+		fn       func(int, *StatsParams) ([]*SendingStatsGroup, *Response, error)
+		expected *SendingStatsGroup
+	}{
+		{"ByDate", "date", `"date":"2024-03-01"`, client.Stats.ByDate, &SendingStatsGroup{Date: "2024-03-01"}},
+		{"ByCategory", "categories", `"category":"Orders"`, client.Stats.ByCategory, &SendingStatsGroup{Category: "Orders"}},
+		{"ByDomain", "domains", `"sending_domain_id":3`, client.Stats.ByDomain, &SendingStatsGroup{SendingDomainID: 3}},
+		{"ByEmailServiceProvider", "email_service_providers", `"email_service_provider":"Google"`,
+			client.Stats.ByEmailServiceProvider, &SendingStatsGroup{EmailServiceProvider: "Google"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		mux.HandleFunc("/accounts/1/stats/"+tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("start_date"); got != "2024-03-01" {
+				t.Errorf("Request start_date is %q, want 2024-03-01", got)
+			}
+			fmt.Fprintf(w, `[{%s,"stats":%s}]`, tt.key, sendingStatsJSON)
+		})
+
+		groups, _, err := tt.fn(1, statsParamsMock())
+		if err != nil {
+			t.Errorf("Stats.%s returned error: %v", tt.name, err)
+		}
+
+		tt.expected.Stats = *sendingStatsMock()
+		if expected := []*SendingStatsGroup{tt.expected}; !reflect.DeepEqual(groups, expected) {
+			t.Errorf("Stats.%s returned %+v, expected %+v", tt.name, groups, expected)
+		}
+
+		testBadPathParams(t, "Stats."+tt.name, func() error {
+			_, _, err = tt.fn(-1, statsParamsMock())
+			return err
+		})
+	}
+}
+
+func TestSendingStats_ComputeRates(t *testing.T) {
+	s := SendingStats{DeliveryCount: 90, BounceCount: 10, OpenCount: 45, ClickCount: 9}
+	s.ComputeRates()
+	if !reflect.DeepEqual(&s, sendingStatsMock()) {
+		t.Errorf("SendingStats.ComputeRates = %+v, want %+v", s, sendingStatsMock())
+	}
+
+	var empty SendingStats
+	empty.ComputeRates()
+	if empty != (SendingStats{}) {
+		t.Errorf("SendingStats.ComputeRates of empty stats = %+v", empty)
+	}
+}
+
+func TestMergeStats(t *testing.T) {
+	groups := []*SendingStatsGroup{
+		{Date: "2024-03-01", Stats: SendingStats{DeliveryCount: 10, OpenCount: 10, DeliveryRate: 1, OpenRate: 1}},
+		{Date: "2024-03-02", Stats: SendingStats{DeliveryCount: 30, BounceCount: 10, SpamCount: 3}},
+	}
+
+	total := TotalStats(groups)
+	expected := SendingStats{
+		DeliveryCount: 40,
+		DeliveryRate:  0.8,
+		BounceCount:   10,
+		BounceRate:    0.2,
+		OpenCount:     10,
+		OpenRate:      0.25,
+		SpamCount:     3,
+		SpamRate:      0.075,
+	}
+	if total != expected {
+		t.Errorf("TotalStats = %+v, want %+v", total, expected)
+	}
+}
+
+func TestCompareStats(t *testing.T) {
+	previous := SendingStats{DeliveryCount: 90, BounceCount: 10, OpenCount: 45}
+	previous.ComputeRates()
+	current := SendingStats{DeliveryCount: 80, BounceCount: 20, OpenCount: 60}
+	current.ComputeRates()
+
+	cmp := CompareStats(previous, current)
+	if cmp.Previous != previous || cmp.Current != current {
+		t.Errorf("CompareStats periods = %+v, %+v", cmp.Previous, cmp.Current)
+	}
+	if cmp.Delta.DeliveryCount != -10 || cmp.Delta.BounceCount != 10 || cmp.Delta.OpenCount != 15 {
+		t.Errorf("CompareStats count deltas = %+v", cmp.Delta)
+	}
+	if math.Abs(cmp.Delta.BounceRate-0.1) > 1e-9 || math.Abs(cmp.Delta.OpenRate-0.25) > 1e-9 {
+		t.Errorf("CompareStats rate deltas = %+v", cmp.Delta)
+	}
+}
+
+func TestStatsParams_PreviousPeriod(t *testing.T) {
+	params := statsParamsMock()
+	params.Categories = []string{"Orders"}
+
+	prev := params.PreviousPeriod()
+	if want := time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC); !prev.StartDate.Equal(want) {
+		t.Errorf("PreviousPeriod start = %v, want %v", prev.StartDate, want)
+	}
+	if want := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC); !prev.EndDate.Equal(want) {
+		t.Errorf("PreviousPeriod end = %v, want %v", prev.EndDate, want)
+	}
+	if !reflect.DeepEqual(prev.Categories, params.Categories) {
+		t.Errorf("PreviousPeriod categories = %v, want %v", prev.Categories, params.Categories)
+	}
+	if !params.StartDate.Equal(statsParamsMock().StartDate) {
+		t.Error("PreviousPeriod modified the params")
+	}
+}
+
+func sendingStatsMock() *SendingStats {
+	return &SendingStats{
+		DeliveryCount: 90,
+		DeliveryRate:  0.9,
+		BounceCount:   10,
+		BounceRate:    0.1,
+		OpenCount:     45,
+		OpenRate:      0.5,
+		ClickCount:    9,
+		ClickRate:     0.1,
+	}
+}
@@ -0,0 +1,340 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SuppressionReason represents the reason why a recipient is suppressed.
+type SuppressionReason string
+
+// Suppression reasons.
+const (
+	SuppressionHardBounce     SuppressionReason = "hard bounce"
+	SuppressionSpamComplaint  SuppressionReason = "spam complaint"
+	SuppressionUnsubscription SuppressionReason = "unsubscription"
+	SuppressionManualImport   SuppressionReason = "manual import"
+)
+
+// SuppressedRecipient represents a recipient in the local suppression list.
+type SuppressedRecipient struct {
+	Email     string            `json:"email"`
+	Reason    SuppressionReason `json:"reason"`
+	Details   string            `json:"details,omitempty"`
+	MessageID string            `json:"message_id,omitempty"`
+	EventID   string            `json:"event_id,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// SuppressionStore persists the suppression list between runs.
+type SuppressionStore interface {
+	Load() ([]*SuppressedRecipient, error)
+	Save(recipients []*SuppressedRecipient) error
+}
+
+// FileSuppressionStore stores the suppression list in a JSON file.
+type FileSuppressionStore struct {
+	Path string
+}
+
+var _ SuppressionStore = &FileSuppressionStore{}
+
+// Load reads the suppression list from the file.
+// A missing file is treated as an empty list.
+func (s *FileSuppressionStore) Load() ([]*SuppressedRecipient, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []*SuppressedRecipient
+	if err := json.Unmarshal(data, &recipients); err != nil {
+		return nil, err
+	}
+
+	return recipients, nil
+}
+
+// Save replaces the file contents with the given suppression list.
+func (s *FileSuppressionStore) Save(recipients []*SuppressedRecipient) error {
+	data, err := json.MarshalIndent(recipients, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// SuppressionMode defines how the suppression filter handles suppressed recipients.
+type SuppressionMode int
+
+const (
+	// SuppressionDrop removes suppressed recipients from the request.
+	SuppressionDrop SuppressionMode = iota
+	// SuppressionReject aborts sending when any recipient is suppressed.
+	SuppressionReject
+)
+
+// SuppressedError is returned by the suppression filter when a request
+// addresses suppressed recipients.
+type SuppressedError struct {
+	Recipients []*SuppressedRecipient
+}
+
+func (e *SuppressedError) Error() string {
+	list := make([]string, 0, len(e.Recipients))
+	for _, r := range e.Recipients {
+		list = append(list, fmt.Sprintf("%s (%s)", r.Email, r.Reason))
+	}
+	return "suppressed recipients: " + strings.Join(list, ", ")
+}
+
+// SuppressionList is a local list of recipients who must not receive emails.
+// It is built from the bounce, spam and unsubscribe webhook events.
+//
+// SuppressionList is safe for concurrent use.
+type SuppressionList struct {
+	mu         sync.RWMutex
+	store      SuppressionStore
+	recipients map[string]*SuppressedRecipient
+}
+
+// NewSuppressionList creates a suppression list and loads it from the store.
+// If store is nil, the list is kept in memory only.
+func NewSuppressionList(store SuppressionStore) (*SuppressionList, error) {
+	l := &SuppressionList{
+		store:      store,
+		recipients: make(map[string]*SuppressedRecipient),
+	}
+	if store == nil {
+		return l, nil
+	}
+
+	recipients, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recipients {
+		l.recipients[normalizeEmail(r.Email)] = r
+	}
+
+	return l, nil
+}
+
+// Apply adds recipients of the hard bounce, spam and unsubscribe events to the list.
+// Other events are ignored. Recipients already in the list keep their original reason.
+func (l *SuppressionList) Apply(events []Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := l.clone()
+	changed := false
+	for _, e := range events {
+		reason, ok := eventSuppressionReason(e.Event)
+		if !ok || e.Email == "" {
+			continue
+		}
+		key := normalizeEmail(e.Email)
+		if _, ok := next[key]; ok {
+			continue
+		}
+
+		createdAt := time.Now().UTC()
+		if !e.Timestamp.IsZero() {
+			createdAt = e.Timestamp.UTC()
+		}
+		details := e.Reason
+		if details == "" {
+			details = e.Response
+		}
+
+		next[key] = &SuppressedRecipient{
+			Email:     e.Email,
+			Reason:    reason,
+			Details:   details,
+			MessageID: e.MessageID,
+			EventID:   e.EventID,
+			CreatedAt: createdAt,
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return l.commit(next)
+}
+
+// Add adds the recipient to the list, replacing an existing entry with the same email.
+func (l *SuppressionList) Add(recipient *SuppressedRecipient) error {
+	if recipient == nil || recipient.Email == "" {
+		return errors.New("suppressed recipient 'email' is required")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := *recipient
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now().UTC()
+	}
+	next := l.clone()
+	next[normalizeEmail(r.Email)] = &r
+
+	return l.commit(next)
+}
+
+// Remove lifts the suppression of the email address.
+func (l *SuppressionList) Remove(email string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := normalizeEmail(email)
+	if _, ok := l.recipients[key]; !ok {
+		return nil
+	}
+	next := l.clone()
+	delete(next, key)
+
+	return l.commit(next)
+}
+
+// Get returns the suppression entry of the email address, if any.
+func (l *SuppressionList) Get(email string) (*SuppressedRecipient, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	r, ok := l.recipients[normalizeEmail(email)]
+	if !ok {
+		return nil, false
+	}
+	rc := *r
+	return &rc, true
+}
+
+// IsSuppressed reports whether the email address is suppressed.
+func (l *SuppressionList) IsSuppressed(email string) bool {
+	_, ok := l.Get(email)
+	return ok
+}
+
+// List returns all suppressed recipients sorted by email.
+func (l *SuppressionList) List() []*SuppressedRecipient {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.list()
+}
+
+// Filter returns a send filter which drops suppressed recipients from the request
+// or rejects the request, depending on mode.
+// The filter always rejects a request whose 'to' recipients are all suppressed.
+//
+// Register it with SendingClient.AddSendFilter.
+func (l *SuppressionList) Filter(mode SuppressionMode) SendFilter {
+	return func(request *SendEmailRequest) error {
+		var suppressed []*SuppressedRecipient
+		filter := func(addresses []EmailAddress) []EmailAddress {
+			var allowed []EmailAddress
+			for _, a := range addresses {
+				if r, ok := l.Get(a.Email); ok {
+					suppressed = append(suppressed, r)
+					continue
+				}
+				allowed = append(allowed, a)
+			}
+			return allowed
+		}
+
+		to := filter(request.To)
+		cc := filter(request.Cc)
+		bcc := filter(request.Bcc)
+		if len(suppressed) == 0 {
+			return nil
+		}
+		if mode == SuppressionReject || len(to) == 0 {
+			return &SuppressedError{Recipients: suppressed}
+		}
+
+		request.To, request.Cc, request.Bcc = to, cc, bcc
+		return nil
+	}
+}
+
+func (l *SuppressionList) list() []*SuppressedRecipient {
+	return sortedRecipients(l.recipients)
+}
+
+func sortedRecipients(m map[string]*SuppressedRecipient) []*SuppressedRecipient {
+	recipients := make([]*SuppressedRecipient, 0, len(m))
+	for _, r := range m {
+		rc := *r
+		recipients = append(recipients, &rc)
+	}
+	sort.Slice(recipients, func(i, j int) bool {
+		return normalizeEmail(recipients[i].Email) < normalizeEmail(recipients[j].Email)
+	})
+	return recipients
+}
+
+// clone returns a copy of the recipients map to be changed and committed.
+func (l *SuppressionList) clone() map[string]*SuppressedRecipient {
+	next := make(map[string]*SuppressedRecipient, len(l.recipients))
+	for k, r := range l.recipients {
+		next[k] = r
+	}
+	return next
+}
+
+// commit saves the recipients to the store and replaces the list with them.
+// The list is left unchanged if saving fails, so the change can be retried.
+func (l *SuppressionList) commit(next map[string]*SuppressedRecipient) error {
+	if l.store != nil {
+		if err := l.store.Save(sortedRecipients(next)); err != nil {
+			return err
+		}
+	}
+	l.recipients = next
+	return nil
+}
+
+func eventSuppressionReason(event string) (SuppressionReason, bool) {
+	switch event {
+	case EventBounce:
+		return SuppressionHardBounce, true
+	case EventSpam:
+		return SuppressionSpamComplaint, true
+	case EventUnsubscribe:
+		return SuppressionUnsubscription, true
+	}
+	return "", false
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
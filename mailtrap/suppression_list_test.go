@@ -0,0 +1,208 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSuppressionList_Apply(t *testing.T) {
+	list, err := NewSuppressionList(nil)
+	if err != nil {
+		t.Fatalf("NewSuppressionList returned error: %v", err)
+	}
+
+	events := []Event{
+		{Event: EventDelivery, Email: "john@example.com", Timestamp: unixTimestamp(1700000000)},
+		{Event: EventBounce, Email: "John@Example.com", Response: "550 5.1.1 User unknown", MessageID: "msg-1", EventID: "evt-1", Timestamp: unixTimestamp(1700000001)},
+		{Event: EventSpam, Email: "mary@example.com", Timestamp: unixTimestamp(1700000002)},
+		{Event: EventUnsubscribe, Email: "mike@example.com", Timestamp: unixTimestamp(1700000003)},
+		{Event: EventSpam, Email: "john@example.com", Timestamp: unixTimestamp(1700000004)},
+		{Event: EventSoftBounce, Email: "soft@example.com", Timestamp: unixTimestamp(1700000005)},
+	}
+	if err := list.Apply(events); err != nil {
+		t.Fatalf("SuppressionList.Apply returned error: %v", err)
+	}
+
+	expected := []*SuppressedRecipient{
+		{
+			Email:     "John@Example.com",
+			Reason:    SuppressionHardBounce,
+			Details:   "550 5.1.1 User unknown",
+			MessageID: "msg-1",
+			EventID:   "evt-1",
+			CreatedAt: time.Unix(1700000001, 0).UTC(),
+		},
+		{Email: "mary@example.com", Reason: SuppressionSpamComplaint, CreatedAt: time.Unix(1700000002, 0).UTC()},
+		{Email: "mike@example.com", Reason: SuppressionUnsubscription, CreatedAt: time.Unix(1700000003, 0).UTC()},
+	}
+	if got := list.List(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("SuppressionList.List returned %+v, expected %+v", got, expected)
+	}
+
+	if !list.IsSuppressed("JOHN@example.com") {
+		t.Error("SuppressionList.IsSuppressed = false, want true")
+	}
+	if list.IsSuppressed("soft@example.com") {
+		t.Error("SuppressionList.IsSuppressed for soft bounce = true, want false")
+	}
+}
+
+func TestSuppressionList_AddRemove(t *testing.T) {
+	list, _ := NewSuppressionList(nil)
+
+	if err := list.Add(&SuppressedRecipient{}); err == nil {
+		t.Error("SuppressionList.Add without email, err = nil, want error")
+	}
+
+	if err := list.Add(&SuppressedRecipient{Email: "john@example.com", Reason: SuppressionUnsubscription}); err != nil {
+		t.Errorf("SuppressionList.Add returned error: %v", err)
+	}
+	r, ok := list.Get("john@example.com")
+	if !ok {
+		t.Fatal("SuppressionList.Get returned no recipient")
+	}
+	if r.CreatedAt.IsZero() {
+		t.Error("SuppressionList.Add did not set 'created_at'")
+	}
+
+	if err := list.Remove("John@example.com"); err != nil {
+		t.Errorf("SuppressionList.Remove returned error: %v", err)
+	}
+	if list.IsSuppressed("john@example.com") {
+		t.Error("SuppressionList.IsSuppressed after Remove = true, want false")
+	}
+}
+
+func TestSuppressionList_fileStore(t *testing.T) {
+	store := &FileSuppressionStore{Path: filepath.Join(t.TempDir(), "suppressions.json")}
+
+	list, err := NewSuppressionList(store)
+	if err != nil {
+		t.Fatalf("NewSuppressionList with missing file returned error: %v", err)
+	}
+	err = list.Apply([]Event{{Event: EventBounce, Email: "john@example.com", Timestamp: unixTimestamp(1700000000)}})
+	if err != nil {
+		t.Fatalf("SuppressionList.Apply returned error: %v", err)
+	}
+
+	reloaded, err := NewSuppressionList(store)
+	if err != nil {
+		t.Fatalf("NewSuppressionList returned error: %v", err)
+	}
+	if !reflect.DeepEqual(reloaded.List(), list.List()) {
+		t.Errorf("Reloaded list is %+v, expected %+v", reloaded.List(), list.List())
+	}
+}
+
+// flakyStore fails the next save if fail is set.
+type flakyStore struct {
+	fail  bool
+	saved []*SuppressedRecipient
+}
+
+func (s *flakyStore) Load() ([]*SuppressedRecipient, error) { return nil, nil }
+
+func (s *flakyStore) Save(recipients []*SuppressedRecipient) error {
+	if s.fail {
+		s.fail = false
+		return errors.New("disk full")
+	}
+	s.saved = recipients
+	return nil
+}
+
+func TestSuppressionList_saveFailed(t *testing.T) {
+	store := &flakyStore{fail: true}
+	list, _ := NewSuppressionList(store)
+
+	events := []Event{{Event: EventBounce, Email: "john@example.com", Timestamp: unixTimestamp(1700000000)}}
+	if err := list.Apply(events); err == nil {
+		t.Fatal("SuppressionList.Apply with failing store, err = nil, want error")
+	}
+	if list.IsSuppressed("john@example.com") {
+		t.Error("SuppressionList.Apply kept the recipient which was not saved")
+	}
+
+	if err := list.Apply(events); err != nil {
+		t.Fatalf("SuppressionList.Apply retry returned error: %v", err)
+	}
+	if len(store.saved) != 1 || store.saved[0].Email != "john@example.com" {
+		t.Errorf("SuppressionList.Apply retry saved %+v", store.saved)
+	}
+
+	store.fail = true
+	if err := list.Remove("john@example.com"); err == nil {
+		t.Error("SuppressionList.Remove with failing store, err = nil, want error")
+	}
+	if !list.IsSuppressed("john@example.com") {
+		t.Error("SuppressionList.Remove removed the recipient although saving failed")
+	}
+
+	store.fail = true
+	if err := list.Add(&SuppressedRecipient{Email: "mary@example.com"}); err == nil {
+		t.Error("SuppressionList.Add with failing store, err = nil, want error")
+	}
+	if list.IsSuppressed("mary@example.com") {
+		t.Error("SuppressionList.Add kept the recipient which was not saved")
+	}
+}
+
+func TestSuppressionList_Filter(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	var sent int
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	list, _ := NewSuppressionList(nil)
+	_ = list.Apply([]Event{
+		{Event: EventBounce, Email: "mike@example.com"},
+		{Event: EventSpam, Email: "info@example.com"},
+	})
+	client.AddSendFilter(list.Filter(SuppressionDrop))
+
+	email := emailRequestMock()
+	if _, _, err := client.Send(email); err != nil {
+		t.Fatalf("SendEmail.Send returned error: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("SendEmail.Send sent %d requests, want 1", sent)
+	}
+	if len(email.To) != 2 || len(email.Cc) != 1 {
+		t.Error("Suppression filter modified the caller's request")
+	}
+
+	email.To = []EmailAddress{{Email: "mike@example.com"}}
+	_, _, err := client.Send(email)
+	var suppressedErr *SuppressedError
+	if !errors.As(err, &suppressedErr) {
+		t.Errorf("SendEmail.Send to suppressed recipients only returned %v, want SuppressedError", err)
+	}
+	if sent != 1 {
+		t.Errorf("SendEmail.Send sent %d requests, want 1", sent)
+	}
+}
+
+func TestSuppressionList_Filter_reject(t *testing.T) {
+	list, _ := NewSuppressionList(nil)
+	_ = list.Apply([]Event{{Event: EventSpam, Email: "info@example.com"}})
+
+	filter := list.Filter(SuppressionReject)
+	if err := filter(&SendEmailRequest{To: []EmailAddress{{Email: "john@example.com"}}}); err != nil {
+		t.Errorf("Filter returned error: %v", err)
+	}
+
+	err := filter(emailRequestMock())
+	const errMessage = "suppressed recipients: info@example.com (spam complaint)"
+	if err == nil || err.Error() != errMessage {
+		t.Errorf("Filter error is %v, want %s", err, errMessage)
+	}
+}
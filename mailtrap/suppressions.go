@@ -0,0 +1,127 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type SuppressionsServiceContract interface {
+	List(accountID int, params *ListSuppressionsParams) ([]*Suppression, *Response, error)
+	Delete(accountID int, suppressionID string) (*Response, error)
+}
+
+type SuppressionsService struct {
+	client *client
+}
+
+var _ SuppressionsServiceContract = &SuppressionsService{}
+
+// Suppression represents a recipient suppressed by Mailtrap.
+type Suppression struct {
+	ID    string            `json:"id"`
+	Type  SuppressionReason `json:"type"`
+	Email string            `json:"email"`
+	// sending_stream can return transactional, bulk, any
+	SendingStream string    `json:"sending_stream"`
+	DomainName    string    `json:"domain_name"`
+	CreatedAt     Timestamp `json:"created_at"`
+
+	// Details of the message which caused the suppression.
+	MessageBounceCategory  string    `json:"message_bounce_category"`
+	MessageCategory        string    `json:"message_category"`
+	MessageClientIP        string    `json:"message_client_ip"`
+	MessageCreatedAt       Timestamp `json:"message_created_at"`
+	MessageESPResponse     string    `json:"message_esp_response"`
+	MessageESPServerType   string    `json:"message_esp_server_type"`
+	MessageOutgoingIP      string    `json:"message_outgoing_ip"`
+	MessageRecipientMXName string    `json:"message_recipient_mx_name"`
+	MessageSenderEmail     string    `json:"message_sender_email"`
+	MessageSubject         string    `json:"message_subject"`
+}
+
+// ListSuppressionsParams represents the available List() query parameters.
+type ListSuppressionsParams struct {
+	// Email returns the suppressions of the email address only.
+	Email string
+	// Type returns the suppressions of the given reason only.
+	Type SuppressionReason
+	// StartTime and EndTime limit the suppression creation time.
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (p *ListSuppressionsParams) values() url.Values {
+	v := url.Values{}
+	if p == nil {
+		return v
+	}
+	if p.Email != "" {
+		v.Set("email", p.Email)
+	}
+	if !p.StartTime.IsZero() {
+		v.Set("start_time", p.StartTime.UTC().Format(time.RFC3339))
+	}
+	return v
+}
+
+// match reports whether the suppression matches the filters which the API doesn't support.
+func (p *ListSuppressionsParams) match(s *Suppression) bool {
+	if p == nil {
+		return true
+	}
+	if p.Type != "" && s.Type != p.Type {
+		return false
+	}
+	if !p.EndTime.IsZero() && s.CreatedAt.After(p.EndTime) {
+		return false
+	}
+	return true
+}
+
+// List returns the suppressed recipients of the account.
+//
+// Email and StartTime filters are applied by the API, Type and EndTime are applied to the results.
+func (s *SuppressionsService) List(
+	accountID int,
+	params *ListSuppressionsParams,
+) ([]*Suppression, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/suppressions", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.values().Encode()
+
+	var suppressions []*Suppression
+	res, err := s.client.Do(req, &suppressions)
+	if err != nil {
+		return nil, res, err
+	}
+
+	filtered := suppressions[:0]
+	for _, sp := range suppressions {
+		if params.match(sp) {
+			filtered = append(filtered, sp)
+		}
+	}
+
+	return filtered, res, nil
+}
+
+// Delete removes the suppression, so that the recipient can receive emails again.
+func (s *SuppressionsService) Delete(accountID int, suppressionID string) (*Response, error) {
+	if suppressionID == "" {
+		return nil, errors.New("suppression ID is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/suppressions/%s", accountID, url.PathEscape(suppressionID))
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
@@ -0,0 +1,163 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSuppressionsService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &Suppression{}, "{}")
+
+	u := suppressionMock("018dd5e3-f6d2-7c00-8f9b-e5c3f2d8a132", SuppressionHardBounce)
+	want := `{
+		"id": "018dd5e3-f6d2-7c00-8f9b-e5c3f2d8a132",
+		"type": "hard bounce",
+		"email": "john@example.com",
+		"sending_stream": "transactional",
+		"domain_name": "example.com",
+		"created_at": "2024-02-26T21:13:52.000Z",
+		"message_bounce_category": "bad mailbox",
+		"message_category": "Welcome email",
+		"message_client_ip": "123.123.123.123",
+		"message_created_at": "2024-02-26T21:13:51.000Z",
+		"message_esp_response": "550 5.1.1 User unknown",
+		"message_esp_server_type": "Google",
+		"message_outgoing_ip": "1.1.1.1",
+		"message_recipient_mx_name": "Google",
+		"message_sender_email": "hello@example.com",
+		"message_subject": "Welcome!"
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestSuppressionsService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	bounce := suppressionMock("1", SuppressionHardBounce)
+	unsubscription := suppressionMock("2", SuppressionUnsubscription)
+	late := suppressionMock("3", SuppressionHardBounce)
+	late.CreatedAt = NewTimestamp(late.CreatedAt.Add(48 * time.Hour))
+
+	mux.HandleFunc("/accounts/1/suppressions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal([]*Suppression{bounce, unsubscription, late})
+		fmt.Fprint(w, string(resp))
+	})
+
+	suppressions, _, err := client.Suppressions.List(1, nil)
+	if err != nil {
+		t.Errorf("Suppressions.List returned error: %v", err)
+	}
+	if len(suppressions) != 3 {
+		t.Errorf("Suppressions.List returned %d suppressions, want 3", len(suppressions))
+	}
+
+	params := &ListSuppressionsParams{
+		Type:    SuppressionHardBounce,
+		EndTime: bounce.CreatedAt.Add(time.Hour),
+	}
+	suppressions, _, err = client.Suppressions.List(1, params)
+	if err != nil {
+		t.Errorf("Suppressions.List returned error: %v", err)
+	}
+	if expected := []*Suppression{bounce}; !reflect.DeepEqual(suppressions, expected) {
+		t.Errorf("Suppressions.List returned %+v, expected %+v", suppressions, expected)
+	}
+
+	testBadPathParams(t, "Suppressions.List", func() error {
+		_, _, err = client.Suppressions.List(-1, nil)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Suppressions.List", &client.client, func() (*Response, error) {
+		suppressions, resp, err := client.Suppressions.List(1, nil)
+		if suppressions != nil {
+			t.Errorf("Suppressions.List client.BaseURL.Host=%v suppressions=%#v, want nil", client.baseURL.Host, suppressions)
+		}
+		return resp, err
+	})
+}
+
+func TestSuppressionsService_List_queryParams(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/suppressions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.RawQuery, "email=john%40example.com&start_time=2024-02-26T00%3A00%3A00Z"; got != want {
+			t.Errorf("Suppressions.List query is %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	params := &ListSuppressionsParams{
+		Email:     "john@example.com",
+		StartTime: time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+	}
+	if _, _, err := client.Suppressions.List(1, params); err != nil {
+		t.Errorf("Suppressions.List returned error: %v", err)
+	}
+}
+
+func TestSuppressionsService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/suppressions/018dd5e3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Suppressions.Delete(1, "018dd5e3")
+	if err != nil {
+		t.Errorf("Suppressions.Delete returned error: %v", err)
+	}
+
+	mux.HandleFunc("/accounts/1/suppressions/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); got != "/accounts/1/suppressions/id%2Fwebhooks%2F5" {
+			t.Errorf("Suppressions.Delete request path = %s, want the ID escaped", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := client.Suppressions.Delete(1, "id/webhooks/5"); err != nil {
+		t.Errorf("Suppressions.Delete with '/' in ID returned error: %v", err)
+	}
+
+	_, err = client.Suppressions.Delete(1, "")
+	if err == nil {
+		t.Error("Suppressions.Delete empty ID, err = nil, want error")
+	}
+
+	testNewRequestAndDoFail(t, "Suppressions.Delete", &client.client, func() (*Response, error) {
+		return client.Suppressions.Delete(1, "018dd5e3")
+	})
+}
+
+func suppressionMock(ID string, reason SuppressionReason) *Suppression {
+	createdAt, _ := ParseTimestamp("2024-02-26T21:13:52.000Z")
+	messageCreatedAt, _ := ParseTimestamp("2024-02-26T21:13:51.000Z")
+
+	return &Suppression{
+		ID:                     ID,
+		Type:                   reason,
+		Email:                  "john@example.com",
+		SendingStream:          "transactional",
+		DomainName:             "example.com",
+		CreatedAt:              createdAt,
+		MessageBounceCategory:  "bad mailbox",
+		MessageCategory:        "Welcome email",
+		MessageClientIP:        "123.123.123.123",
+		MessageCreatedAt:       messageCreatedAt,
+		MessageESPResponse:     "550 5.1.1 User unknown",
+		MessageESPServerType:   "Google",
+		MessageOutgoingIP:      "1.1.1.1",
+		MessageRecipientMXName: "Google",
+		MessageSenderEmail:     "hello@example.com",
+		MessageSubject:         "Welcome!",
+	}
+}
@@ -0,0 +1,426 @@
+package mailtrap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TemplateAction is the action planned for an email template.
+type TemplateAction string
+
+const (
+	TemplateCreate TemplateAction = "create"
+	TemplateUpdate TemplateAction = "update"
+	TemplateDelete TemplateAction = "delete"
+)
+
+// TemplateChange represents a planned change of an email template.
+type TemplateChange struct {
+	Action TemplateAction
+	Name   string
+	// Current is the template of the account, nil for create.
+	Current *EmailTemplate
+	// Desired is the local template, nil for delete.
+	Desired *EmailTemplateRequest
+}
+
+// TemplatePlan represents the changes needed to bring the account templates
+// in line with the local ones.
+type TemplatePlan struct {
+	Changes []*TemplateChange
+}
+
+// TemplateSync synchronizes the account email templates with local templates.
+// Templates are matched by name.
+type TemplateSync struct {
+	Templates EmailTemplatesServiceContract
+	AccountID int
+
+	// Prune deletes the account templates missing locally.
+	Prune bool
+
+	// DryRun only computes the plan without applying it.
+	DryRun bool
+}
+
+// LoadTemplates reads the email templates from the directory of fsys.
+//
+// A template consists of NAME.html and, optionally, NAME.txt files with the
+// HTML and text bodies. The HTML file, or the text file for text-only templates,
+// starts with a front-matter block of "key: value" lines between "---" lines:
+//
+//	---
+//	name: Welcome
+//	subject: Welcome, {{user_name}}
+//	category: Onboarding
+//	---
+//	<p>Hello, {{user_name}}</p>
+//
+// The name defaults to NAME. The subject and category are required.
+func LoadTemplates(fsys fs.FS, dir string) ([]*EmailTemplateRequest, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bases := map[string]bool{}
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if e.IsDir() || (ext != ".html" && ext != ".txt") {
+			continue
+		}
+		bases[strings.TrimSuffix(e.Name(), ext)] = true
+	}
+
+	var (
+		templates []*EmailTemplateRequest
+		names     = map[string]string{}
+	)
+	for base := range bases {
+		tmpl, err := loadTemplate(fsys, path.Join(dir, base))
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[tmpl.Name]; ok {
+			return nil, fmt.Errorf("template %q is defined by both %s and %s", tmpl.Name, other, base)
+		}
+		names[tmpl.Name] = base
+		templates = append(templates, tmpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	return templates, nil
+}
+
+func loadTemplate(fsys fs.FS, base string) (*EmailTemplateRequest, error) {
+	html, htmlErr := fs.ReadFile(fsys, base+".html")
+	if htmlErr != nil && !errors.Is(htmlErr, fs.ErrNotExist) {
+		return nil, htmlErr
+	}
+	text, textErr := fs.ReadFile(fsys, base+".txt")
+	if textErr != nil && !errors.Is(textErr, fs.ErrNotExist) {
+		return nil, textErr
+	}
+
+	tmpl := &EmailTemplateRequest{Name: path.Base(base)}
+	file := base + ".html"
+	content := string(html)
+	if htmlErr != nil {
+		file = base + ".txt"
+		content = string(text)
+	}
+
+	meta, body, err := parseFrontMatter(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for k, v := range meta {
+		switch k {
+		case "name":
+			tmpl.Name = v
+		case "subject":
+			tmpl.Subject = v
+		case "category":
+			tmpl.Category = v
+		default:
+			return nil, fmt.Errorf("%s: unknown front-matter key %q", file, k)
+		}
+	}
+	if tmpl.Subject == "" || tmpl.Category == "" {
+		return nil, fmt.Errorf("%s: front-matter 'subject' and 'category' are required", file)
+	}
+
+	if htmlErr == nil {
+		tmpl.BodyHTML = normalizeTemplateBody(body)
+		if textErr == nil {
+			tmpl.BodyText = normalizeTemplateBody(string(text))
+		}
+	} else {
+		tmpl.BodyText = normalizeTemplateBody(body)
+	}
+
+	return tmpl, nil
+}
+
+// parseFrontMatter splits the leading "---" delimited block of "key: value" lines from the content.
+func parseFrontMatter(content string) (map[string]string, string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, "", errors.New("front-matter is missing")
+	}
+
+	// Keep the new line of the opening delimiter, so that an empty block is found as well.
+	rest := content[len("---"):]
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n---") {
+			return nil, "", errors.New("front-matter is not closed")
+		}
+		end = len(rest) - len("\n---")
+	}
+
+	meta := map[string]string{}
+	for _, line := range strings.Split(rest[:end], "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 {
+			return nil, "", fmt.Errorf("invalid front-matter line %q", line)
+		}
+		k := strings.TrimSpace(line[:i])
+		v := strings.TrimSpace(line[i+1:])
+		if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			if v[0] == '"' {
+				unquoted, err := strconv.Unquote(v)
+				if err != nil {
+					return nil, "", fmt.Errorf("invalid front-matter value %s", v)
+				}
+				v = unquoted
+			} else {
+				v = v[1 : len(v)-1]
+			}
+		}
+		meta[k] = v
+	}
+
+	body := ""
+	if end+len("\n---\n") <= len(rest) {
+		body = rest[end+len("\n---\n"):]
+	}
+
+	return meta, body, nil
+}
+
+// normalizeTemplateBody makes the bodies comparable regardless of line endings
+// and trailing new lines.
+func normalizeTemplateBody(body string) string {
+	return strings.TrimRight(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+}
+
+// Plan compares the local templates with the account ones and returns the needed changes.
+//
+// Empty local bodies are not managed: the API can not clear them, so they never cause updates.
+func (s *TemplateSync) Plan(local []*EmailTemplateRequest) (*TemplatePlan, error) {
+	remote, _, err := s.Templates.List(s.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*EmailTemplate, len(remote))
+	for _, t := range remote {
+		if _, ok := byName[t.Name]; ok {
+			return nil, fmt.Errorf("account has several templates named %q", t.Name)
+		}
+		byName[t.Name] = t
+	}
+
+	plan := &TemplatePlan{}
+	seen := make(map[string]bool, len(local))
+	for _, desired := range local {
+		if seen[desired.Name] {
+			return nil, fmt.Errorf("template %q is defined several times", desired.Name)
+		}
+		seen[desired.Name] = true
+
+		current, ok := byName[desired.Name]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, &TemplateChange{Action: TemplateCreate, Name: desired.Name, Desired: desired})
+		case len(templateFieldChanges(current, desired)) > 0:
+			plan.Changes = append(plan.Changes, &TemplateChange{
+				Action:  TemplateUpdate,
+				Name:    desired.Name,
+				Current: current,
+				Desired: desired,
+			})
+		}
+	}
+
+	if s.Prune {
+		for _, current := range remote {
+			if !seen[current.Name] {
+				plan.Changes = append(plan.Changes, &TemplateChange{Action: TemplateDelete, Name: current.Name, Current: current})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool { return plan.Changes[i].Name < plan.Changes[j].Name })
+
+	return plan, nil
+}
+
+// Apply applies the plan changes in order. It stops at the first failed change.
+func (s *TemplateSync) Apply(plan *TemplatePlan) error {
+	for _, c := range plan.Changes {
+		var err error
+		switch c.Action {
+		case TemplateCreate:
+			_, _, err = s.Templates.Create(s.AccountID, c.Desired)
+		case TemplateUpdate:
+			_, _, err = s.Templates.Update(s.AccountID, c.Current.ID, c.Desired)
+		case TemplateDelete:
+			_, err = s.Templates.Delete(s.AccountID, c.Current.ID)
+		default:
+			err = fmt.Errorf("unknown action %q", c.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("%s template %q: %w", c.Action, c.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Sync loads the templates from the directory of fsys, plans the changes and,
+// unless DryRun is set, applies them. The plan is returned in both cases.
+func (s *TemplateSync) Sync(fsys fs.FS, dir string) (*TemplatePlan, error) {
+	local, err := LoadTemplates(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.Plan(local)
+	if err != nil {
+		return nil, err
+	}
+	if s.DryRun {
+		return plan, nil
+	}
+
+	return plan, s.Apply(plan)
+}
+
+// Empty reports whether the account templates are up to date.
+func (p *TemplatePlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// WriteDiff writes the human readable plan to w.
+func (p *TemplatePlan) WriteDiff(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if p.Empty() {
+		fmt.Fprintln(bw, "No changes. Templates are up to date.")
+		return bw.Flush()
+	}
+
+	var created, updated, deleted int
+	for _, c := range p.Changes {
+		switch c.Action {
+		case TemplateCreate:
+			created++
+			fmt.Fprintf(bw, "+ create %q\n", c.Name)
+			for _, f := range templateFieldChanges(&EmailTemplate{}, c.Desired) {
+				writeFieldDiff(bw, f)
+			}
+		case TemplateUpdate:
+			updated++
+			fmt.Fprintf(bw, "~ update %q (id %d)\n", c.Name, c.Current.ID)
+			for _, f := range templateFieldChanges(c.Current, c.Desired) {
+				writeFieldDiff(bw, f)
+			}
+		case TemplateDelete:
+			deleted++
+			fmt.Fprintf(bw, "- delete %q (id %d)\n", c.Name, c.Current.ID)
+		}
+	}
+	fmt.Fprintf(bw, "\nPlan: %d to create, %d to update, %d to delete.\n", created, updated, deleted)
+
+	return bw.Flush()
+}
+
+type templateFieldChange struct {
+	field    string
+	old, new string
+}
+
+func templateFieldChanges(current *EmailTemplate, desired *EmailTemplateRequest) []templateFieldChange {
+	var changes []templateFieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, templateFieldChange{field: field, old: old, new: new})
+		}
+	}
+
+	add("subject", current.Subject, desired.Subject)
+	add("category", current.Category, desired.Category)
+	if desired.BodyHTML != "" {
+		add("body_html", normalizeTemplateBody(current.BodyHTML), normalizeTemplateBody(desired.BodyHTML))
+	}
+	if desired.BodyText != "" {
+		add("body_text", normalizeTemplateBody(current.BodyText), normalizeTemplateBody(desired.BodyText))
+	}
+
+	return changes
+}
+
+func writeFieldDiff(w io.Writer, f templateFieldChange) {
+	fmt.Fprintf(w, "    %s:\n", f.field)
+	for _, line := range diffLines(f.old, f.new) {
+		fmt.Fprintf(w, "      %s\n", line)
+	}
+}
+
+// diffLines returns the line diff of a and b, prefixing the lines
+// with "  " if unchanged, "- " if removed and "+ " if added.
+func diffLines(a, b string) []string {
+	var x, y []string
+	if a != "" {
+		x = strings.Split(a, "\n")
+	}
+	if b != "" {
+		y = strings.Split(b, "\n")
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			switch {
+			case x[i] == y[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			diff = append(diff, "  "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+x[i])
+			i++
+		default:
+			diff = append(diff, "+ "+y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		diff = append(diff, "- "+x[i])
+	}
+	for ; j < len(y); j++ {
+		diff = append(diff, "+ "+y[j])
+	}
+
+	return diff
+}
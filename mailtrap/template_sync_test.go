@@ -0,0 +1,302 @@
+package mailtrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func templatesFS() fstest.MapFS {
+	return fstest.MapFS{
+		"templates/welcome.html": {Data: []byte("---\nname: Welcome\nsubject: \"Welcome, {{user_name}}\"\ncategory: Onboarding\n---\n<p>Hello, {{user_name}}</p>\n<p>Thanks for joining.</p>\n")},
+		"templates/welcome.txt":  {Data: []byte("Hello, {{user_name}}\r\n")},
+		"templates/reset.txt":    {Data: []byte("---\nsubject: Reset password\ncategory: Security\n---\nReset link: {{url}}\n")},
+		"templates/README.md":    {Data: []byte("# Templates\n")},
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	templates, err := LoadTemplates(templatesFS(), "templates")
+	if err != nil {
+		t.Fatalf("LoadTemplates returned error: %v", err)
+	}
+
+	expected := []*EmailTemplateRequest{
+		{Name: "Welcome", Subject: "Welcome, {{user_name}}", Category: "Onboarding", BodyHTML: "<p>Hello, {{user_name}}</p>\n<p>Thanks for joining.</p>", BodyText: "Hello, {{user_name}}"},
+		{Name: "reset", Subject: "Reset password", Category: "Security", BodyText: "Reset link: {{url}}"},
+	}
+	if !reflect.DeepEqual(templates, expected) {
+		t.Errorf("LoadTemplates returned %+v, expected %+v", templates, expected)
+	}
+}
+
+func TestLoadTemplates_invalid(t *testing.T) {
+	tests := map[string]string{
+		"no front-matter":      "<p>Hi</p>",
+		"not closed":           "---\nsubject: Hi\ncategory: c\n<p>Hi</p>",
+		"missing subject":      "---\ncategory: c\n---\n<p>Hi</p>",
+		"unknown key":          "---\nsubject: Hi\ncategory: c\nfrom: me\n---\n<p>Hi</p>",
+		"invalid line":         "---\nsubject Hi\n---\n<p>Hi</p>",
+		"invalid quoted value": "---\nsubject: \"Hi\\x\"\ncategory: c\n---\n<p>Hi</p>",
+	}
+	for name, content := range tests {
+		fsys := fstest.MapFS{"t/a.html": {Data: []byte(content)}}
+		if _, err := LoadTemplates(fsys, "t"); err == nil {
+			t.Errorf("LoadTemplates with %s, err = nil, want error", name)
+		}
+	}
+
+	fsys := fstest.MapFS{
+		"t/a.html": {Data: []byte("---\nname: Same\nsubject: Hi\ncategory: c\n---\n")},
+		"t/b.html": {Data: []byte("---\nname: Same\nsubject: Hi\ncategory: c\n---\n")},
+	}
+	if _, err := LoadTemplates(fsys, "t"); err == nil {
+		t.Error("LoadTemplates with duplicate names, err = nil, want error")
+	}
+
+	if _, err := LoadTemplates(fsys, "missing"); err == nil {
+		t.Error("LoadTemplates with missing directory, err = nil, want error")
+	}
+}
+
+// fakeTemplatesServer serves the email templates API backed by memory.
+type fakeTemplatesServer struct {
+	mu        sync.Mutex
+	nextID    int
+	templates map[int]*EmailTemplate
+	requests  []string
+}
+
+func newFakeTemplatesServer(t *testing.T, mux *http.ServeMux, templates ...*EmailTemplate) *fakeTemplatesServer {
+	f := &fakeTemplatesServer{templates: map[int]*EmailTemplate{}}
+	for _, tmpl := range templates {
+		f.templates[tmpl.ID] = tmpl
+		if tmpl.ID > f.nextID {
+			f.nextID = tmpl.ID
+		}
+	}
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			list := []*EmailTemplate{}
+			for id := 1; id <= f.nextID; id++ {
+				if tmpl, ok := f.templates[id]; ok {
+					list = append(list, tmpl)
+				}
+			}
+			json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			var req emailTemplateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode create request: %v", err)
+				return
+			}
+			f.nextID++
+			tmpl := &EmailTemplate{ID: f.nextID}
+			applyTemplateRequest(tmpl, req.EmailTemplate)
+			f.templates[tmpl.ID] = tmpl
+			json.NewEncoder(w).Encode(tmpl)
+		}
+	})
+	mux.HandleFunc("/accounts/1/email_templates/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+		var id int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/accounts/1/email_templates/"), "%d", &id)
+		tmpl, ok := f.templates[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPatch:
+			var req emailTemplateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode update request: %v", err)
+				return
+			}
+			applyTemplateRequest(tmpl, req.EmailTemplate)
+			json.NewEncoder(w).Encode(tmpl)
+		case http.MethodDelete:
+			delete(f.templates, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	return f
+}
+
+func applyTemplateRequest(tmpl *EmailTemplate, req *EmailTemplateRequest) {
+	for dst, src := range map[*string]string{
+		&tmpl.Name:     req.Name,
+		&tmpl.Subject:  req.Subject,
+		&tmpl.Category: req.Category,
+		&tmpl.BodyHTML: req.BodyHTML,
+		&tmpl.BodyText: req.BodyText,
+	} {
+		if src != "" {
+			*dst = src
+		}
+	}
+}
+
+func (f *fakeTemplatesServer) writes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var writes []string
+	for _, r := range f.requests {
+		if !strings.HasPrefix(r, "GET ") {
+			writes = append(writes, r)
+		}
+	}
+	return writes
+}
+
+func TestTemplateSync_Sync(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	fake := newFakeTemplatesServer(t, mux,
+		&EmailTemplate{
+			ID:       1,
+			Name:     "Welcome",
+			Subject:  "Welcome!",
+			Category: "Onboarding",
+			BodyHTML: "<p>Hello, {{user_name}}</p>\n<p>Thanks for signing up.</p>",
+			BodyText: "Hello, {{user_name}}",
+		},
+		&EmailTemplate{ID: 2, Name: "Legacy", Subject: "Old", Category: "Misc", BodyText: "Old"},
+	)
+
+	sync := &TemplateSync{Templates: client.EmailTemplates, AccountID: 1, Prune: true, DryRun: true}
+
+	plan, err := sync.Sync(templatesFS(), "templates")
+	if err != nil {
+		t.Fatalf("TemplateSync.Sync returned error: %v", err)
+	}
+	if writes := fake.writes(); len(writes) != 0 {
+		t.Errorf("TemplateSync.Sync dry run sent %v, want no writes", writes)
+	}
+
+	var actions []string
+	for _, c := range plan.Changes {
+		actions = append(actions, string(c.Action)+" "+c.Name)
+	}
+	if want := []string{"delete Legacy", "update Welcome", "create reset"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("TemplateSync.Sync planned %v, want %v", actions, want)
+	}
+
+	var diff bytes.Buffer
+	if err := plan.WriteDiff(&diff); err != nil {
+		t.Fatalf("TemplatePlan.WriteDiff returned error: %v", err)
+	}
+	wantDiff := `- delete "Legacy" (id 2)
+~ update "Welcome" (id 1)
+    subject:
+      - Welcome!
+      + Welcome, {{user_name}}
+    body_html:
+        <p>Hello, {{user_name}}</p>
+      - <p>Thanks for signing up.</p>
+      + <p>Thanks for joining.</p>
++ create "reset"
+    subject:
+      + Reset password
+    category:
+      + Security
+    body_text:
+      + Reset link: {{url}}
+
+Plan: 1 to create, 1 to update, 1 to delete.
+`
+	if diff.String() != wantDiff {
+		t.Errorf("TemplatePlan.WriteDiff wrote\n%s\nwant\n%s", diff.String(), wantDiff)
+	}
+
+	sync.DryRun = false
+	if _, err := sync.Sync(templatesFS(), "templates"); err != nil {
+		t.Fatalf("TemplateSync.Sync returned error: %v", err)
+	}
+	wantWrites := []string{
+		"DELETE /accounts/1/email_templates/2",
+		"PATCH /accounts/1/email_templates/1",
+		"POST /accounts/1/email_templates",
+	}
+	if writes := fake.writes(); !reflect.DeepEqual(writes, wantWrites) {
+		t.Errorf("TemplateSync.Sync sent %v, want %v", writes, wantWrites)
+	}
+
+	// The account is in sync now.
+	plan, err = sync.Sync(templatesFS(), "templates")
+	if err != nil {
+		t.Fatalf("TemplateSync.Sync returned error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("TemplateSync.Sync after apply planned %+v, want no changes", plan.Changes)
+	}
+	diff.Reset()
+	plan.WriteDiff(&diff)
+	if diff.String() != "No changes. Templates are up to date.\n" {
+		t.Errorf("TemplatePlan.WriteDiff wrote %q for empty plan", diff.String())
+	}
+}
+
+func TestTemplateSync_PlanWithoutPrune(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	newFakeTemplatesServer(t, mux, &EmailTemplate{ID: 1, Name: "Legacy", Subject: "Old", Category: "Misc", BodyText: "Old"})
+
+	sync := &TemplateSync{Templates: client.EmailTemplates, AccountID: 1}
+	plan, err := sync.Plan(nil)
+	if err != nil {
+		t.Fatalf("TemplateSync.Plan returned error: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("TemplateSync.Plan without prune planned %+v, want no changes", plan.Changes)
+	}
+}
+
+func TestTemplateSync_ApplyFail(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"errors":["name has already been taken"]}`)
+	})
+
+	sync := &TemplateSync{Templates: client.EmailTemplates, AccountID: 1}
+	plan := &TemplatePlan{Changes: []*TemplateChange{{
+		Action:  TemplateCreate,
+		Name:    "Welcome",
+		Desired: &EmailTemplateRequest{Name: "Welcome", Subject: "Hi", Category: "c", BodyText: "Hi"},
+	}}}
+	err := sync.Apply(plan)
+	if err == nil || !strings.Contains(err.Error(), `create template "Welcome"`) {
+		t.Errorf("TemplateSync.Apply returned error %v, want create error", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc", "a\nc\nd")
+	want := []string{"  a", "- b", "  c", "+ d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package mailtrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp represents a time decoded from any of the formats returned by the API:
+// RFC 3339 with or without fractional seconds, Unix time in seconds or milliseconds, or null.
+// The zero Timestamp is encoded as null, other timestamps are encoded in RFC 3339 format.
+type Timestamp struct {
+	time.Time
+}
+
+// unixMillisThreshold separates Unix times in seconds from the ones in milliseconds.
+// Times in seconds above it are after the year 33000, in milliseconds below it are before 2001.
+const unixMillisThreshold = 1e12
+
+// timestampLayouts are the accepted string layouts. The layouts without
+// a time zone are interpreted as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// NewTimestamp returns the timestamp of the time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses the timestamp from a string in any of the accepted formats.
+// An empty string is parsed as the zero timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" || s == "null" {
+		return Timestamp{}, nil
+	}
+	if isUnixTime(s) {
+		return parseUnixTimestamp(s)
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+func isUnixTime(s string) bool {
+	if s[0] == '-' {
+		s = s[1:]
+	}
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseUnixTimestamp(s string) (Timestamp, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	if math.Abs(f) >= unixMillisThreshold {
+		return Timestamp{Time: time.UnixMilli(int64(f)).UTC()}, nil
+	}
+	sec, frac := math.Modf(f)
+	return Timestamp{Time: time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()}, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		ts, err := ParseTimestamp(s)
+		if err != nil {
+			return err
+		}
+		*t = ts
+		return nil
+	}
+
+	ts, err := ParseTimestamp(string(data))
+	if err != nil {
+		return err
+	}
+	*t = ts
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+// String returns the time formatted in RFC 3339 format, or an empty string for the zero timestamp.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339Nano)
+}
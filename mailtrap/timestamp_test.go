@@ -0,0 +1,94 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want time.Time
+	}{
+		{"RFC 3339", `"2023-02-14T19:29:59Z"`, time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)},
+		{"fractional seconds", `"2023-02-14T19:29:59.295Z"`, time.Date(2023, 2, 14, 19, 29, 59, 295000000, time.UTC)},
+		{"time zone offset", `"2023-02-14T21:29:59.295+02:00"`, time.Date(2023, 2, 14, 19, 29, 59, 295000000, time.UTC)},
+		{"without time zone", `"2023-02-14T19:29:59.295"`, time.Date(2023, 2, 14, 19, 29, 59, 295000000, time.UTC)},
+		{"Unix seconds", `1676402999`, time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)},
+		{"Unix fractional seconds", `1676402999.5`, time.Date(2023, 2, 14, 19, 29, 59, 500000000, time.UTC)},
+		{"Unix milliseconds", `1676402999295`, time.Date(2023, 2, 14, 19, 29, 59, 295000000, time.UTC)},
+		{"Unix seconds string", `"1676402999"`, time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)},
+		{"null", `null`, time.Time{}},
+		{"empty string", `""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			err := json.Unmarshal([]byte(tt.data), &ts)
+			if err != nil {
+				t.Fatalf("json.Unmarshal(%s) returned error: %v", tt.data, err)
+			}
+			if !ts.Time.Equal(tt.want) {
+				t.Errorf("json.Unmarshal(%s) = %v, want %v", tt.data, ts.Time, tt.want)
+			}
+		})
+	}
+
+	for _, data := range []string{`"yesterday"`, `true`, `{}`, `"2023-02-30T00:00:00Z"`, `"2023-02-14 19:29:59 UTC"`, `1e400`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("json.Unmarshal(%s), err = nil, want error", data)
+		}
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	tests := map[string]Timestamp{
+		`null`:                       {},
+		`"2023-02-14T19:29:59Z"`:     NewTimestamp(time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)),
+		`"2023-02-14T19:29:59.295Z"`: NewTimestamp(time.Date(2023, 2, 14, 19, 29, 59, 295000000, time.UTC)),
+	}
+	for want, ts := range tests {
+		data, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("json.Marshal(%v) returned error: %v", ts, err)
+		}
+		if string(data) != want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", ts, data, want)
+		}
+
+		var decoded Timestamp
+		if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Time.Equal(ts.Time) {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, decoded, err, ts)
+		}
+	}
+
+	if s := (Timestamp{}).String(); s != "" {
+		t.Errorf("Timestamp.String of zero timestamp = %q, want empty", s)
+	}
+}
+
+func TestEvent_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Event{Event: EventDelivery, Timestamp: unixTimestamp(1700000000)})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	want := `{"event":"delivery","email":"","category":"","message_id":"","custom_variables":null,"event_id":"",` +
+		`"response":"","response_code":0,"reason":"","ip":"","user_agent":"","url":"","timestamp":1700000000}`
+	if string(data) != want {
+		t.Errorf("json.Marshal(Event) = %s, want %s", data, want)
+	}
+
+	data, _ = json.Marshal(Event{})
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded["timestamp"] != nil {
+		t.Errorf("json.Marshal of event without timestamp = %s", data)
+	}
+}
+
+func unixTimestamp(sec int64) Timestamp {
+	return NewTimestamp(time.Unix(sec, 0).UTC())
+}
@@ -1,10 +1,29 @@
 package mailtrap
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 )
 
+// WebhookSignatureHeader is the request header containing the webhook payload signature.
+const WebhookSignatureHeader = "Mailtrap-Signature"
+
+// Webhook event types.
+const (
+	EventDelivery    = "delivery"
+	EventSoftBounce  = "soft bounce"
+	EventBounce      = "bounce"
+	EventSuspension  = "suspension"
+	EventUnsubscribe = "unsubscribe"
+	EventOpen        = "open"
+	EventSpam        = "spam"
+	EventClick       = "click"
+	EventReject      = "reject"
+)
+
 // Events is the wrapper around the Webhook event.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/b9cdfe3d25137-receive-events
@@ -20,7 +39,7 @@ type Event struct {
 	MessageID       string            `json:"message_id"`
 	CustomVariables map[string]string `json:"custom_variables"`
 	EventID         string            `json:"event_id"`
-	Timestamp       int               `json:"timestamp"`
+	Timestamp       Timestamp         `json:"timestamp"`
 	Response        string            `json:"response"`
 	ResponseCode    int               `json:"response_code"`
 	Reason          string            `json:"reason"`
@@ -29,6 +48,22 @@ type Event struct {
 	URL             string            `json:"url"`
 }
 
+// MarshalJSON implements the json.Marshaler interface. The timestamp is encoded
+// as Unix time in seconds, as in the webhook payloads sent by Mailtrap.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	var timestamp *int64
+	if !e.Timestamp.IsZero() {
+		unix := e.Timestamp.Unix()
+		timestamp = &unix
+	}
+	return json.Marshal(struct {
+		event
+		Timestamp *int64 `json:"timestamp"`
+	}{event(e), timestamp})
+}
+
+// DecodeWebhook decodes webhook events from the request body.
 func DecodeWebhook(r io.Reader) (*Events, error) {
 	e := new(Events)
 	if err := json.NewDecoder(r).Decode(&e); err != nil {
@@ -36,3 +71,21 @@ func DecodeWebhook(r io.Reader) (*Events, error) {
 	}
 	return e, nil
 }
+
+// SignWebhook returns the hex-encoded HMAC-SHA256 signature of the webhook payload.
+func SignWebhook(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature is valid for the webhook payload.
+func VerifyWebhookSignature(payload []byte, signature, secret string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
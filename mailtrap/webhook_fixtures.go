@@ -0,0 +1,131 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// FixtureGenerator produces realistic webhook events for testing webhook consumers.
+//
+// Generated identifiers are derived from the seed, so the same seed always
+// yields the same sequence of events.
+type FixtureGenerator struct {
+	// Email is the recipient of the events. Defaults to "john@example.com".
+	Email string
+
+	// Category and CustomVariables are copied to every event.
+	Category        string
+	CustomVariables map[string]string
+
+	// Secret is used to sign payloads. Payloads are not signed if it is empty.
+	Secret string
+
+	// Now returns the time of the events. Defaults to time.Now.
+	Now func() time.Time
+
+	rand *rand.Rand
+}
+
+// NewFixtureGenerator creates a fixture generator.
+func NewFixtureGenerator(seed int64) *FixtureGenerator {
+	return &FixtureGenerator{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Event returns an event of the given type.
+// A random message ID is generated if messageID is empty.
+func (g *FixtureGenerator) Event(eventType, messageID string) Event {
+	if messageID == "" {
+		messageID = g.uuid()
+	}
+	email := g.Email
+	if email == "" {
+		email = "john@example.com"
+	}
+	now := time.Now
+	if g.Now != nil {
+		now = g.Now
+	}
+
+	e := Event{
+		Event:           eventType,
+		Email:           email,
+		Category:        g.Category,
+		MessageID:       messageID,
+		CustomVariables: copyStringMap(g.CustomVariables),
+		EventID:         g.uuid(),
+		Timestamp:       NewTimestamp(now().UTC().Truncate(time.Second)),
+	}
+
+	switch eventType {
+	case EventSoftBounce:
+		e.Response = "421 4.7.0 Temporary System Problem. Try again later."
+		e.ResponseCode = 421
+	case EventBounce:
+		e.Response = "550 5.1.1 The email account that you tried to reach does not exist."
+		e.ResponseCode = 550
+	case EventSuspension, EventReject:
+		e.Reason = "unknown"
+	case EventOpen, EventUnsubscribe:
+		e.IP = g.ip()
+		e.UserAgent = fixtureUserAgent
+	case EventClick:
+		e.IP = g.ip()
+		e.UserAgent = fixtureUserAgent
+		e.URL = fmt.Sprintf("https://example.com/links/%d", g.random().Intn(1000))
+	}
+
+	return e
+}
+
+// Events returns events of the given types for a single message.
+// A random message ID is generated if messageID is empty.
+func (g *FixtureGenerator) Events(messageID string, eventTypes ...string) *Events {
+	if messageID == "" {
+		messageID = g.uuid()
+	}
+
+	events := &Events{Events: make([]Event, 0, len(eventTypes))}
+	for _, t := range eventTypes {
+		events.Events = append(events.Events, g.Event(t, messageID))
+	}
+	return events
+}
+
+// Payload encodes events as a webhook request body.
+// The signature is empty if the generator has no secret.
+func (g *FixtureGenerator) Payload(events *Events) (body []byte, signature string, err error) {
+	body, err = json.Marshal(events)
+	if err != nil {
+		return nil, "", err
+	}
+	if g.Secret != "" {
+		signature = SignWebhook(body, g.Secret)
+	}
+	return body, signature, nil
+}
+
+const fixtureUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 " +
+	"(KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+func (g *FixtureGenerator) random() *rand.Rand {
+	if g.rand == nil {
+		g.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return g.rand
+}
+
+func (g *FixtureGenerator) uuid() string {
+	var b [16]byte
+	g.random().Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (g *FixtureGenerator) ip() string {
+	// 192.0.2.0/24 is reserved for documentation.
+	return fmt.Sprintf("192.0.2.%d", 1+g.random().Intn(254))
+}
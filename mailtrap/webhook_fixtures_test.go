@@ -0,0 +1,95 @@
+package mailtrap
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestFixtureGenerator_Event(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := NewFixtureGenerator(1)
+	g.Category = "Password reset"
+	g.CustomVariables = map[string]string{"user_id": "45982"}
+	g.Now = func() time.Time { return now }
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	tests := []struct {
+		event string
+		check func(e Event) bool
+	}{
+		{EventDelivery, func(e Event) bool { return e.Response == "" }},
+		{EventSoftBounce, func(e Event) bool { return e.ResponseCode == 421 }},
+		{EventBounce, func(e Event) bool { return e.ResponseCode == 550 && e.Response != "" }},
+		{EventReject, func(e Event) bool { return e.Reason != "" }},
+		{EventOpen, func(e Event) bool { return e.IP != "" && e.UserAgent != "" }},
+		{EventClick, func(e Event) bool { return e.URL != "" && e.IP != "" }},
+	}
+
+	for _, tt := range tests {
+		e := g.Event(tt.event, "")
+		if e.Event != tt.event {
+			t.Errorf("FixtureGenerator.Event(%q) type is %q", tt.event, e.Event)
+		}
+		if !uuid.MatchString(e.MessageID) || !uuid.MatchString(e.EventID) {
+			t.Errorf("FixtureGenerator.Event(%q) IDs %q, %q are not UUIDs", tt.event, e.MessageID, e.EventID)
+		}
+		if e.Email != "john@example.com" || e.Category != "Password reset" || e.Timestamp.Unix() != 1700000000 {
+			t.Errorf("FixtureGenerator.Event(%q) returned %+v", tt.event, e)
+		}
+		if !reflect.DeepEqual(e.CustomVariables, g.CustomVariables) {
+			t.Errorf("FixtureGenerator.Event(%q) custom variables are %v", tt.event, e.CustomVariables)
+		}
+		if !tt.check(e) {
+			t.Errorf("FixtureGenerator.Event(%q) returned unrealistic event %+v", tt.event, e)
+		}
+	}
+}
+
+func TestFixtureGenerator_deterministic(t *testing.T) {
+	now := func() time.Time { return time.Unix(1700000000, 0) }
+	g1, g2 := NewFixtureGenerator(42), NewFixtureGenerator(42)
+	g1.Now, g2.Now = now, now
+
+	e1 := g1.Events("", EventDelivery, EventOpen, EventClick)
+	e2 := g2.Events("", EventDelivery, EventOpen, EventClick)
+	if !reflect.DeepEqual(e1, e2) {
+		t.Errorf("Generators with the same seed returned %+v and %+v", e1, e2)
+	}
+
+	for _, e := range e1.Events {
+		if e.MessageID != e1.Events[0].MessageID {
+			t.Errorf("FixtureGenerator.Events returned different message IDs")
+		}
+	}
+}
+
+func TestFixtureGenerator_Payload(t *testing.T) {
+	g := NewFixtureGenerator(1)
+	events := g.Events("msg-1", EventDelivery)
+
+	body, signature, err := g.Payload(events)
+	if err != nil {
+		t.Fatalf("FixtureGenerator.Payload returned error: %v", err)
+	}
+	if signature != "" {
+		t.Errorf("FixtureGenerator.Payload without secret signature is %q, want empty", signature)
+	}
+
+	decoded, err := DecodeWebhook(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("DecodeWebhook returned error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, events) {
+		t.Errorf("DecodeWebhook returned %+v, expected %+v", decoded, events)
+	}
+
+	g.Secret = "secret"
+	body, signature, _ = g.Payload(events)
+	if !VerifyWebhookSignature(body, signature, "secret") {
+		t.Error("FixtureGenerator.Payload signature is invalid")
+	}
+}
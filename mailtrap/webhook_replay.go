@@ -0,0 +1,96 @@
+package mailtrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+const defaultReplayBatchSize = 100
+
+// WebhookReplayer posts recorded webhook events to a webhook endpoint,
+// e.g. a handler running locally.
+type WebhookReplayer struct {
+	// URL of the webhook endpoint.
+	URL string
+
+	// Secret is used to sign the requests. Requests are not signed if it is empty.
+	Secret string
+
+	// BatchSize is the maximum number of events per request. Defaults to 100.
+	BatchSize int
+
+	// HTTPClient used to post the events. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Replay reads events in the JSON Lines format, as written by JSONLinesSink,
+// and posts them to the endpoint in batches.
+// It returns the number of events accepted by the endpoint.
+func (p *WebhookReplayer) Replay(r io.Reader) (int, error) {
+	if p.URL == "" {
+		return 0, errors.New("replay 'url' is required")
+	}
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReplayBatchSize
+	}
+
+	var (
+		dec   = json.NewDecoder(r)
+		batch = make([]Event, 0, batchSize)
+		sent  int
+	)
+	for {
+		var e Event
+		err := dec.Decode(&e)
+		if err != nil && err != io.EOF {
+			return sent, err
+		}
+		if err == nil {
+			batch = append(batch, e)
+		}
+
+		if len(batch) == batchSize || (err == io.EOF && len(batch) > 0) {
+			if perr := p.post(batch); perr != nil {
+				return sent, perr
+			}
+			sent += len(batch)
+			batch = batch[:0]
+		}
+		if err == io.EOF {
+			return sent, nil
+		}
+	}
+}
+
+func (p *WebhookReplayer) post(events []Event) error {
+	body, err := json.Marshal(&Events{Events: events})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if p.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(body, p.Secret))
+	}
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp)
+}
@@ -0,0 +1,79 @@
+package mailtrap
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWebhookReplayer_Replay(t *testing.T) {
+	var (
+		batches  []int
+		received []Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := ioutil.ReadAll(r.Body)
+		if !VerifyWebhookSignature(body, r.Header.Get(WebhookSignatureHeader), "secret") {
+			t.Error("Replayed request signature is invalid")
+		}
+		events, err := DecodeWebhook(bytes.NewReader(body))
+		if err != nil {
+			t.Errorf("DecodeWebhook returned error: %v", err)
+			return
+		}
+		batches = append(batches, len(events.Events))
+		received = append(received, events.Events...)
+	}))
+	defer server.Close()
+
+	g := NewFixtureGenerator(1)
+	events := g.Events("", EventDelivery, EventOpen, EventClick, EventUnsubscribe, EventSpam)
+
+	var recorded bytes.Buffer
+	_ = NewJSONLinesSink(&recorded).Write(events.Events)
+
+	replayer := &WebhookReplayer{URL: server.URL, Secret: "secret", BatchSize: 2}
+	n, err := replayer.Replay(&recorded)
+	if err != nil {
+		t.Fatalf("WebhookReplayer.Replay returned error: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("WebhookReplayer.Replay sent %d events, want 5", n)
+	}
+	if !reflect.DeepEqual(batches, []int{2, 2, 1}) {
+		t.Errorf("WebhookReplayer.Replay sent batches %v, want [2 2 1]", batches)
+	}
+	if !reflect.DeepEqual(received, events.Events) {
+		t.Errorf("Handler received %+v, expected %+v", received, events.Events)
+	}
+}
+
+func TestWebhookReplayer_Replay_errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := (&WebhookReplayer{}).Replay(strings.NewReader("")); err == nil {
+		t.Error("WebhookReplayer.Replay without URL, err = nil, want error")
+	}
+
+	replayer := &WebhookReplayer{URL: server.URL}
+	n, err := replayer.Replay(strings.NewReader(`{"event":"delivery"}` + "\n"))
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("WebhookReplayer.Replay returned %v, want ErrorResponse", err)
+	}
+	if n != 0 {
+		t.Errorf("WebhookReplayer.Replay sent %d events, want 0", n)
+	}
+
+	_, err = replayer.Replay(strings.NewReader(`{"event": `))
+	if err == nil {
+		t.Error("WebhookReplayer.Replay malformed input, err = nil, want error")
+	}
+}
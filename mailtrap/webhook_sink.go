@@ -0,0 +1,387 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrSinkFull is returned by a sink which cannot accept more events.
+//
+// FanOut reports it as a failure of the whole batch, so that Mailtrap retries the delivery.
+var ErrSinkFull = errors.New("sink is full")
+
+// Sink consumes decoded webhook events.
+type Sink interface {
+	Write(events []Event) error
+}
+
+// SinkFunc is an adapter to allow the use of ordinary functions as sinks.
+type SinkFunc func(events []Event) error
+
+// Write calls f(events).
+func (f SinkFunc) Write(events []Event) error {
+	return f(events)
+}
+
+// BackpressurePolicy defines how a channel sink behaves when its buffer is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits until the consumer frees the buffer.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest discards the oldest buffered events.
+	BackpressureDropOldest
+	// BackpressureFail rejects the batch with ErrSinkFull.
+	BackpressureFail
+)
+
+// ChannelSink delivers webhook events to a buffered Go channel.
+type ChannelSink struct {
+	// writeSem serializes the writes, which may block on the channel.
+	// Unlike a mutex, waiting for it can be given up.
+	writeSem chan struct{}
+	// mu guards closed and dropped, it is never held while blocking.
+	mu      sync.Mutex
+	events  chan Event
+	done    chan struct{}
+	policy  BackpressurePolicy
+	closed  bool
+	dropped int
+}
+
+var _ Sink = &ChannelSink{}
+
+// errChannelSinkClosed is returned by the writes to a closed channel sink.
+var errChannelSinkClosed = errors.New("channel sink is closed")
+
+// NewChannelSink creates a channel sink with the buffer of the given size.
+func NewChannelSink(size int, policy BackpressurePolicy) *ChannelSink {
+	return &ChannelSink{
+		writeSem: make(chan struct{}, 1),
+		events:   make(chan Event, size),
+		done:     make(chan struct{}),
+		policy:   policy,
+	}
+}
+
+// Events returns the channel to receive events from.
+// The channel is closed by Close.
+func (s *ChannelSink) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events discarded by the BackpressureDropOldest policy.
+func (s *ChannelSink) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Write puts events to the channel according to the backpressure policy.
+// A write blocked by the BackpressureBlock policy is interrupted by Close.
+func (s *ChannelSink) Write(events []Event) error {
+	return s.write(events, nil)
+}
+
+// write is like Write, but gives up with ErrSinkTimeout once cancel is closed.
+// The events sent before that stay in the channel.
+func (s *ChannelSink) write(events []Event, cancel <-chan struct{}) error {
+	select {
+	case s.writeSem <- struct{}{}:
+	case <-s.done:
+		return errChannelSinkClosed
+	case <-cancel:
+		return ErrSinkTimeout
+	}
+	defer func() { <-s.writeSem }()
+
+	if s.isClosed() {
+		return errChannelSinkClosed
+	}
+
+	switch s.policy {
+	case BackpressureFail:
+		if len(events) > cap(s.events)-len(s.events) {
+			return ErrSinkFull
+		}
+		for _, e := range events {
+			s.events <- e
+		}
+	case BackpressureDropOldest:
+		for _, e := range events {
+			if cap(s.events) == 0 {
+				if !s.trySend(e) {
+					s.addDropped()
+				}
+				continue
+			}
+			for !s.trySend(e) {
+				select {
+				case <-s.events:
+					s.addDropped()
+				default:
+				}
+			}
+		}
+	default:
+		for _, e := range events {
+			select {
+			case s.events <- e:
+			case <-s.done:
+				return errChannelSinkClosed
+			case <-cancel:
+				return ErrSinkTimeout
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close wakes up the blocked writes and closes the events channel.
+func (s *ChannelSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+
+	// Wait for the current write to return before closing the channel it sends to.
+	// The semaphore is never released, the later writes see the sink closed.
+	s.writeSem <- struct{}{}
+	close(s.events)
+
+	return nil
+}
+
+func (s *ChannelSink) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *ChannelSink) addDropped() {
+	s.mu.Lock()
+	s.dropped++
+	s.mu.Unlock()
+}
+
+func (s *ChannelSink) trySend(e Event) bool {
+	select {
+	case s.events <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// JSONLinesSink writes webhook events to w in the JSON Lines format, one event per line.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+var _ Sink = &JSONLinesSink{}
+
+// NewJSONLinesSink creates a sink which writes events to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// Write encodes events to the underlying writer.
+func (s *JSONLinesSink) Write(events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range events {
+		if err := s.enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrSinkTimeout is reported when a sink does not finish writing the events within the FanOut timeout.
+var ErrSinkTimeout = errors.New("sink timed out")
+
+// DefaultSinkTimeout is the default time FanOut waits for each sink.
+const DefaultSinkTimeout = 5 * time.Second
+
+// FanOut delivers webhook events to several sinks concurrently.
+//
+// Sink errors are isolated: they are passed to OnError and do not fail the batch,
+// except for ErrSinkFull which is returned to make Mailtrap retry the delivery.
+// A sink which does not finish within Timeout is reported with ErrSinkTimeout,
+// so a stalled consumer does not hold up the response. A ChannelSink stops writing then.
+// Other sinks can't be interrupted and keep writing in the background; they are skipped
+// and reported with ErrSinkTimeout until that write finishes.
+// FanOut can be used as an http.Handler for the webhook endpoint.
+type FanOut struct {
+	// OnError is called with the sink name and its error. Optional.
+	OnError func(name string, err error)
+	// Timeout limits the wait for each sink. Defaults to DefaultSinkTimeout.
+	Timeout time.Duration
+
+	sinks []namedSink
+
+	mu sync.Mutex
+	// busy contains the indexes of the sinks still writing a timed out batch.
+	busy map[int]bool
+}
+
+type namedSink struct {
+	name string
+	sink Sink
+}
+
+// cancelableSink is implemented by the sinks whose writes FanOut can interrupt on timeout.
+type cancelableSink interface {
+	write(events []Event, cancel <-chan struct{}) error
+}
+
+var _ cancelableSink = &ChannelSink{}
+
+type sinkResult struct {
+	index int
+	err   error
+}
+
+var (
+	_ Sink         = &FanOut{}
+	_ http.Handler = &FanOut{}
+)
+
+// NewFanOut creates an empty fan-out.
+func NewFanOut() *FanOut {
+	return &FanOut{}
+}
+
+// Add registers the sink under the given name.
+func (f *FanOut) Add(name string, sink Sink) {
+	f.sinks = append(f.sinks, namedSink{name: name, sink: sink})
+}
+
+// Write delivers events to all sinks and waits for them to finish or time out.
+func (f *FanOut) Write(events []Event) error {
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSinkTimeout
+	}
+
+	errs := make([]error, len(f.sinks))
+	finished := make([]bool, len(f.sinks))
+	// cancel interrupts the writes of the cancelable sinks which are still running on return.
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	// The results channel is buffered, so the sinks which time out don't leak blocked goroutines.
+	results := make(chan sinkResult, len(f.sinks))
+	started := 0
+	for i, s := range f.sinks {
+		if !f.acquire(i) {
+			errs[i] = fmt.Errorf("%w: previous write is still pending", ErrSinkTimeout)
+			finished[i] = true
+			continue
+		}
+		started++
+		go func(i int, s namedSink) {
+			var err error
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("sink panic: %v", r)
+				}
+				f.release(i)
+				results <- sinkResult{index: i, err: err}
+			}()
+			if cs, ok := s.sink.(cancelableSink); ok {
+				err = cs.write(events, cancel)
+			} else {
+				err = s.sink.Write(events)
+			}
+		}(i, s)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+wait:
+	for pending := started; pending > 0; pending-- {
+		select {
+		case r := <-results:
+			errs[r.index] = r.err
+			finished[r.index] = true
+		case <-timer.C:
+			for i := range f.sinks {
+				if !finished[i] {
+					errs[i] = fmt.Errorf("%w after %v", ErrSinkTimeout, timeout)
+				}
+			}
+			break wait
+		}
+	}
+
+	var retryErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if f.OnError != nil {
+			f.OnError(f.sinks[i].name, err)
+		}
+		if errors.Is(err, ErrSinkFull) && retryErr == nil {
+			retryErr = fmt.Errorf("sink %q: %w", f.sinks[i].name, err)
+		}
+	}
+
+	return retryErr
+}
+
+// acquire marks the sink busy, unless it is busy already.
+func (f *FanOut) acquire(i int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.busy[i] {
+		return false
+	}
+	if f.busy == nil {
+		f.busy = make(map[int]bool)
+	}
+	f.busy[i] = true
+	return true
+}
+
+func (f *FanOut) release(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.busy, i)
+}
+
+// ServeHTTP decodes the webhook request and delivers its events to the sinks.
+// It responds with 400 for malformed payloads and 503 when the batch has to be retried.
+func (f *FanOut) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	events, err := DecodeWebhook(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := f.Write(events.Events); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
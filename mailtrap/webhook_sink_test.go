@@ -0,0 +1,316 @@
+package mailtrap
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func sinkEvents(n int) []Event {
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = Event{Event: EventDelivery, MessageID: string(rune('a' + i))}
+	}
+	return events
+}
+
+func TestChannelSink_block(t *testing.T) {
+	sink := NewChannelSink(3, BackpressureBlock)
+	events := sinkEvents(3)
+
+	if err := sink.Write(events); err != nil {
+		t.Fatalf("ChannelSink.Write returned error: %v", err)
+	}
+	sink.Close()
+
+	var got []Event
+	for e := range sink.Events() {
+		got = append(got, e)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("ChannelSink received %+v, expected %+v", got, events)
+	}
+
+	if err := sink.Write(events); err == nil {
+		t.Error("ChannelSink.Write after Close, err = nil, want error")
+	}
+}
+
+func TestChannelSink_blockClose(t *testing.T) {
+	sink := NewChannelSink(1, BackpressureBlock)
+
+	// Nobody consumes the events, so the write blocks on the second one.
+	written := make(chan error, 1)
+	go func() { written <- sink.Write(sinkEvents(2)) }()
+
+	select {
+	case err := <-written:
+		t.Fatalf("ChannelSink.Write to a full channel returned %v, want it to block", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	dropped := make(chan int, 1)
+	go func() { dropped <- sink.Dropped() }()
+	select {
+	case <-dropped:
+	case <-time.After(time.Second):
+		t.Fatal("ChannelSink.Dropped hangs while a write is blocked")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		sink.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("ChannelSink.Close hangs while a write is blocked")
+	}
+
+	select {
+	case err := <-written:
+		if err == nil {
+			t.Error("ChannelSink.Write interrupted by Close, err = nil, want error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ChannelSink.Write is still blocked after Close")
+	}
+}
+
+func TestChannelSink_dropOldest(t *testing.T) {
+	sink := NewChannelSink(2, BackpressureDropOldest)
+	events := sinkEvents(5)
+
+	if err := sink.Write(events); err != nil {
+		t.Fatalf("ChannelSink.Write returned error: %v", err)
+	}
+	sink.Close()
+
+	var got []Event
+	for e := range sink.Events() {
+		got = append(got, e)
+	}
+	if !reflect.DeepEqual(got, events[3:]) {
+		t.Errorf("ChannelSink received %+v, expected %+v", got, events[3:])
+	}
+	if sink.Dropped() != 3 {
+		t.Errorf("ChannelSink.Dropped is %d, want 3", sink.Dropped())
+	}
+}
+
+func TestChannelSink_fail(t *testing.T) {
+	sink := NewChannelSink(2, BackpressureFail)
+
+	if err := sink.Write(sinkEvents(3)); !errors.Is(err, ErrSinkFull) {
+		t.Errorf("ChannelSink.Write returned %v, want ErrSinkFull", err)
+	}
+	if len(sink.Events()) != 0 {
+		t.Errorf("ChannelSink buffered %d events of the rejected batch", len(sink.Events()))
+	}
+	if err := sink.Write(sinkEvents(2)); err != nil {
+		t.Errorf("ChannelSink.Write returned error: %v", err)
+	}
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+
+	events := []Event{
+		{Event: EventDelivery, Email: "john@example.com", MessageID: "1"},
+		{Event: EventOpen, Email: "john@example.com", MessageID: "1"},
+	}
+	if err := sink.Write(events); err != nil {
+		t.Fatalf("JSONLinesSink.Write returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("JSONLinesSink wrote %d lines, want 2", len(lines))
+	}
+	for i, line := range lines {
+		testJSONMarshal(t, &events[i], line)
+	}
+}
+
+func TestFanOut_Write(t *testing.T) {
+	fanOut := NewFanOut()
+
+	var (
+		mu      sync.Mutex
+		errored []string
+	)
+	fanOut.OnError = func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errored = append(errored, name)
+	}
+
+	var received []Event
+	fanOut.Add("collector", SinkFunc(func(events []Event) error {
+		received = append(received, events...)
+		return nil
+	}))
+	fanOut.Add("broken", SinkFunc(func(events []Event) error {
+		return errors.New("crm is down")
+	}))
+	fanOut.Add("panicking", SinkFunc(func(events []Event) error {
+		panic("boom")
+	}))
+
+	events := sinkEvents(2)
+	if err := fanOut.Write(events); err != nil {
+		t.Errorf("FanOut.Write returned error: %v", err)
+	}
+	if !reflect.DeepEqual(received, events) {
+		t.Errorf("FanOut delivered %+v, expected %+v", received, events)
+	}
+	if len(errored) != 2 {
+		t.Errorf("FanOut.OnError called for %v, want broken and panicking sinks", errored)
+	}
+
+	fanOut.Add("full", NewChannelSink(0, BackpressureFail))
+	if err := fanOut.Write(events); !errors.Is(err, ErrSinkFull) {
+		t.Errorf("FanOut.Write returned %v, want ErrSinkFull", err)
+	}
+}
+
+func TestFanOut_Write_stalledSink(t *testing.T) {
+	fanOut := NewFanOut()
+	fanOut.Timeout = 50 * time.Millisecond
+
+	var (
+		mu      sync.Mutex
+		errored = map[string]error{}
+	)
+	fanOut.OnError = func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errored[name] = err
+	}
+
+	// The consumer of the stalled sink never reads the events.
+	stalled := NewChannelSink(0, BackpressureBlock)
+	defer stalled.Close()
+	fanOut.Add("stalled", stalled)
+
+	var received []Event
+	fanOut.Add("collector", SinkFunc(func(events []Event) error {
+		received = append(received, events...)
+		return nil
+	}))
+
+	srv := httptest.NewServer(fanOut)
+	defer srv.Close()
+
+	start := time.Now()
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"events":[{"event":"delivery"}]}`))
+	if err != nil {
+		t.Fatalf("POST returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("FanOut responded after %v, want the stalled sink to time out", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("FanOut responded with %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if len(received) != 1 {
+		t.Errorf("FanOut delivered %+v to the collector", received)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !errors.Is(errored["stalled"], ErrSinkTimeout) || len(errored) != 1 {
+		t.Errorf("FanOut.OnError called with %v, want ErrSinkTimeout for the stalled sink", errored)
+	}
+}
+
+func TestFanOut_Write_repeatedTimeouts(t *testing.T) {
+	fanOut := NewFanOut()
+	fanOut.Timeout = 10 * time.Millisecond
+
+	var timeouts int
+	fanOut.OnError = func(name string, err error) {
+		if errors.Is(err, ErrSinkTimeout) {
+			timeouts++
+		}
+	}
+
+	stalled := NewChannelSink(0, BackpressureBlock)
+	defer stalled.Close()
+	fanOut.Add("stalled", stalled)
+
+	release := make(chan struct{})
+	var calls int32
+	fanOut.Add("blocked", SinkFunc(func(events []Event) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	}))
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 50; i++ {
+		if err := fanOut.Write(sinkEvents(1)); err != nil {
+			t.Fatalf("FanOut.Write returned error: %v", err)
+		}
+	}
+	// The interrupted channel sink writes may need a moment to exit.
+	time.Sleep(10 * time.Millisecond)
+	if after := runtime.NumGoroutine(); after > before+2 {
+		t.Errorf("FanOut.Write left %d goroutines after repeated timeouts, had %d", after, before)
+	}
+	close(release)
+
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("FanOut.Write called the pending sink %d times, want 1", calls)
+	}
+	if timeouts != 100 {
+		t.Errorf("FanOut.OnError reported %d timeouts, want 100", timeouts)
+	}
+	select {
+	case e := <-stalled.Events():
+		t.Errorf("ChannelSink received %+v after the write timed out", e)
+	default:
+	}
+}
+
+func TestFanOut_ServeHTTP(t *testing.T) {
+	fanOut := NewFanOut()
+	sink := NewChannelSink(1, BackpressureFail)
+	fanOut.Add("channel", sink)
+
+	tests := []struct {
+		method string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, `{{"bad": "json"}}`, http.StatusBadRequest},
+		{http.MethodPost, `{"events":[{"event":"delivery","email":"john@example.com"}]}`, http.StatusOK},
+		{http.MethodPost, `{"events":[{"event":"open","email":"john@example.com"}]}`, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "/webhooks", strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		fanOut.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("FanOut.ServeHTTP(%s %s) status is %d, want %d", tt.method, tt.body, w.Code, tt.status)
+		}
+	}
+
+	if e := <-sink.Events(); e.Event != EventDelivery {
+		t.Errorf("FanOut delivered %+v, want delivery event", e)
+	}
+}
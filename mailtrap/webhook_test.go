@@ -40,7 +40,7 @@ func TestWebhook_DecodeWebhook(t *testing.T) {
 			MessageID:       "12345678-abcd-efgh-yyyy-1111111111",
 			EventID:         "98765432-abcd-edfg-xxxx-2222222222",
 			CustomVariables: map[string]string{"user_id": "45982", "batch_id": "PSJ-12"},
-			Timestamp:       123456789011,
+			Timestamp:       unixTimestamp(123456789011),
 		},
 	}}
 
@@ -56,3 +56,21 @@ func TestWebhook_DecodeWebhook(t *testing.T) {
 		t.Error("DecodeWebhook err = nil, want error")
 	}
 }
+
+func TestWebhook_Signature(t *testing.T) {
+	payload := []byte(`{"events":[{"event":"delivery"}]}`)
+	signature := SignWebhook(payload, "secret")
+
+	if !VerifyWebhookSignature(payload, signature, "secret") {
+		t.Error("VerifyWebhookSignature = false, want true")
+	}
+	if VerifyWebhookSignature(payload, signature, "another") {
+		t.Error("VerifyWebhookSignature with another secret = true, want false")
+	}
+	if VerifyWebhookSignature([]byte(`{"events":[]}`), signature, "secret") {
+		t.Error("VerifyWebhookSignature with modified payload = true, want false")
+	}
+	if VerifyWebhookSignature(payload, "not-hex", "secret") {
+		t.Error("VerifyWebhookSignature with malformed signature = true, want false")
+	}
+}
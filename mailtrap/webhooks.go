@@ -0,0 +1,121 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type WebhooksServiceContract interface {
+	List(accountID int) ([]*Webhook, *Response, error)
+	Get(accountID, webhookID int) (*Webhook, *Response, error)
+	Create(accountID int, createReq *WebhookRequest) (*Webhook, *Response, error)
+	Update(accountID, webhookID int, updateReq *WebhookRequest) (*Webhook, *Response, error)
+	Delete(accountID, webhookID int) (*Response, error)
+}
+
+type WebhooksService struct {
+	client *client
+}
+
+var _ WebhooksServiceContract = &WebhooksService{}
+
+// Webhook represents a Mailtrap webhook endpoint.
+type Webhook struct {
+	ID     int    `json:"id"`
+	URL    string `json:"url"`
+	Active bool   `json:"active"`
+	// webhook_type can return email_sending, audit_log
+	WebhookType string `json:"webhook_type"`
+	// payload_format can return json, jsonlines
+	PayloadFormat string `json:"payload_format"`
+	// sending_stream can return transactional, bulk
+	SendingStream string `json:"sending_stream"`
+	// DomainID is the sending domain ID, or 0 for all domains.
+	DomainID   int      `json:"domain_id"`
+	EventTypes []string `json:"event_types"`
+	// SigningSecret is used to verify the webhook payloads, see VerifyWebhookSignature.
+	SigningSecret string `json:"signing_secret"`
+}
+
+// WebhookRequest represents the request to create / update webhook.
+// The event types are validated by the API, their names may differ
+// from the event names in the webhook payloads.
+type WebhookRequest struct {
+	URL           string   `json:"url,omitempty"`
+	Active        *bool    `json:"active,omitempty"`
+	WebhookType   string   `json:"webhook_type,omitempty"`
+	PayloadFormat string   `json:"payload_format,omitempty"`
+	SendingStream string   `json:"sending_stream,omitempty"`
+	DomainID      int      `json:"domain_id,omitempty"`
+	EventTypes    []string `json:"event_types,omitempty"`
+}
+
+type webhookRequest struct {
+	Webhook *WebhookRequest `json:"webhook"`
+}
+
+// List returns all webhooks of the account.
+func (s *WebhooksService) List(accountID int) ([]*Webhook, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/webhooks", accountID)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var webhooks []*Webhook
+	res, err := s.client.Do(req, &webhooks)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return webhooks, res, nil
+}
+
+// Get returns the webhook by ID, including its signing secret.
+func (s *WebhooksService) Get(accountID, webhookID int) (*Webhook, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/webhooks/%d", accountID, webhookID)
+	return s.makeRequest(u, http.MethodGet, nil)
+}
+
+// Create creates a webhook subscribed to the given event types.
+func (s *WebhooksService) Create(accountID int, createReq *WebhookRequest) (*Webhook, *Response, error) {
+	if createReq == nil || createReq.URL == "" {
+		return nil, nil, errors.New("webhook 'url' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/webhooks", accountID)
+	return s.makeRequest(u, http.MethodPost, &webhookRequest{Webhook: createReq})
+}
+
+// Update updates the webhook URL, status or event types.
+func (s *WebhooksService) Update(accountID, webhookID int, updateReq *WebhookRequest) (*Webhook, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/webhooks/%d", accountID, webhookID)
+	return s.makeRequest(u, http.MethodPatch, &webhookRequest{Webhook: updateReq})
+}
+
+// Delete removes the webhook.
+func (s *WebhooksService) Delete(accountID, webhookID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/webhooks/%d", accountID, webhookID)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *WebhooksService) makeRequest(endpoint, httpMethod string, payload interface{}) (*Webhook, *Response, error) {
+	req, err := s.client.NewRequest(httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var webhook *Webhook
+	res, err := s.client.Do(req, &webhook)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return webhook, res, nil
+}
@@ -0,0 +1,186 @@
+package mailtrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWebhooksService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &Webhook{}, "{}")
+
+	u := webhookMock(1)
+	want := `{
+		"id": 1,
+		"url": "https://example.com/webhooks",
+		"active": true,
+		"webhook_type": "email_sending",
+		"payload_format": "json",
+		"sending_stream": "transactional",
+		"domain_id": 3,
+		"event_types": ["delivery", "bounce", "spam"],
+		"signing_secret": "a1b2c3"
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestWebhooksService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedWebhooks := []*Webhook{webhookMock(1), webhookMock(2)}
+
+	mux.HandleFunc("/accounts/1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedWebhooks)
+		fmt.Fprint(w, string(resp))
+	})
+
+	webhooks, _, err := client.Webhooks.List(1)
+	if err != nil {
+		t.Errorf("Webhooks.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(webhooks, expectedWebhooks) {
+		t.Errorf("Webhooks.List returned %+v, expected %+v", webhooks, expectedWebhooks)
+	}
+
+	testBadPathParams(t, "Webhooks.List", func() error {
+		_, _, err = client.Webhooks.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Webhooks.List", &client.client, func() (*Response, error) {
+		webhooks, resp, err := client.Webhooks.List(1)
+		if webhooks != nil {
+			t.Errorf("Webhooks.List client.BaseURL.Host=%v webhooks=%#v, want nil", client.baseURL.Host, webhooks)
+		}
+		return resp, err
+	})
+}
+
+func TestWebhooksService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedWebhook := webhookMock(2)
+
+	mux.HandleFunc("/accounts/1/webhooks/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedWebhook)
+		fmt.Fprint(w, string(resp))
+	})
+
+	webhook, _, err := client.Webhooks.Get(1, 2)
+	if err != nil {
+		t.Errorf("Webhooks.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(webhook, expectedWebhook) {
+		t.Errorf("Webhooks.Get returned %+v, expected %+v", webhook, expectedWebhook)
+	}
+
+	testBadPathParams(t, "Webhooks.Get", func() error {
+		_, _, err = client.Webhooks.Get(-1, -2)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Webhooks.Get", &client.client, func() (*Response, error) {
+		webhook, resp, err := client.Webhooks.Get(1, 2)
+		if webhook != nil {
+			t.Errorf("Webhooks.Get client.BaseURL.Host=%v webhook=%#v, want nil", client.baseURL.Host, webhook)
+		}
+		return resp, err
+	})
+}
+
+func TestWebhooksService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"webhook":{"url":"https://example.com/webhooks","event_types":["delivery","bounce"]}}`)
+		fmt.Fprint(w, `{"id":1,"url":"https://example.com/webhooks","active":true,"signing_secret":"a1b2c3"}`)
+	})
+
+	createReq := &WebhookRequest{
+		URL:        "https://example.com/webhooks",
+		EventTypes: []string{"delivery", "bounce"},
+	}
+	webhook, _, err := client.Webhooks.Create(1, createReq)
+	if err != nil {
+		t.Errorf("Webhooks.Create returned error: %v", err)
+	}
+
+	expected := &Webhook{ID: 1, URL: "https://example.com/webhooks", Active: true, SigningSecret: "a1b2c3"}
+	if !reflect.DeepEqual(webhook, expected) {
+		t.Errorf("Webhooks.Create returned %+v, expected %+v", webhook, expected)
+	}
+}
+
+func TestWebhooksService_Create_invalid(t *testing.T) {
+	client, _, teardown := setupTestingClient()
+	defer teardown()
+
+	_, _, err := client.Webhooks.Create(1, &WebhookRequest{})
+	if err == nil || err.Error() != "webhook 'url' is required" {
+		t.Errorf("Webhooks.Create error is %v, want 'url' is required", err)
+	}
+}
+
+func TestWebhooksService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/webhooks/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"webhook":{"active":false}}`)
+		fmt.Fprint(w, `{"id":2,"active":false}`)
+	})
+
+	active := false
+	webhook, _, err := client.Webhooks.Update(1, 2, &WebhookRequest{Active: &active})
+	if err != nil {
+		t.Errorf("Webhooks.Update returned error: %v", err)
+	}
+
+	expected := &Webhook{ID: 2, Active: false}
+	if !reflect.DeepEqual(webhook, expected) {
+		t.Errorf("Webhooks.Update returned %+v, expected %+v", webhook, expected)
+	}
+}
+
+func TestWebhooksService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/webhooks/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+	})
+
+	_, err := client.Webhooks.Delete(1, 2)
+	if err != nil {
+		t.Errorf("Webhooks.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "Webhooks.Delete", &client.client, func() (*Response, error) {
+		return client.Webhooks.Delete(1, 2)
+	})
+}
+
+func webhookMock(ID int) *Webhook {
+	return &Webhook{
+		ID:            ID,
+		URL:           "https://example.com/webhooks",
+		Active:        true,
+		WebhookType:   "email_sending",
+		PayloadFormat: "json",
+		SendingStream: "transactional",
+		DomainID:      3,
+		EventTypes:    []string{"delivery", "bounce", "spam"},
+		SigningSecret: "a1b2c3",
+	}
+}